@@ -61,7 +61,8 @@ if $DO_GENERATE; then
 fi
 
 echo "Building..."
-go build -ldflags "-s -w" -mod=readonly -mod=vendor -o ./bin/options ./main/main.go
+VERSION=$(git describe --tags --always --dirty 2>/dev/null || echo dev)
+go build -ldflags "-s -w -X github.com/object88/options/generate.Version=$VERSION" -mod=readonly -mod=vendor -o ./bin/options ./main/main.go
 
 if $DO_TEST; then
   echo "Running tests"
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/object88/options/loader"
+	"github.com/pkg/errors"
+)
+
+// argument is a parsed command line argument, which names a package, by
+// directory or import path, and the structs in it to generate.  Without
+// struct names, the structs marked with the directive are generated, in the
+// package or, for a `/...` pattern, in every package beneath it.
+type argument struct {
+	pkg       string
+	recursive bool
+	structs   []string
+}
+
+// parseArgument parses an argument of the form `package[:Struct[,Struct]]`
+// or `package/...`
+func parseArgument(arg string) (*argument, error) {
+	if strings.TrimSpace(arg) == "" {
+		return nil, errors.Errorf("Empty argument; expected 'package:Struct[,Struct]' or 'package/...'")
+	}
+
+	a := &argument{pkg: arg}
+	names, hasNames := "", false
+	if i := strings.LastIndex(arg, ":"); i != -1 {
+		a.pkg, names, hasNames = arg[:i], arg[i+1:], true
+	}
+
+	if strings.HasSuffix(a.pkg, "...") {
+		a.recursive = true
+		a.pkg = strings.TrimSuffix(strings.TrimSuffix(a.pkg, "..."), "/")
+		if a.pkg == "" {
+			a.pkg = "."
+		}
+	}
+
+	if a.pkg == "" {
+		return nil, errors.Errorf("Argument '%s' has no package before ':'; expected 'package:Struct[,Struct]'", arg)
+	}
+	if !hasNames {
+		return a, nil
+	}
+
+	if a.recursive {
+		return nil, errors.Errorf("Argument '%s' names structs, which cannot be combined with a '/...' pattern", arg)
+	}
+	if names == "" {
+		return nil, errors.Errorf("Argument '%s' has no structs after ':'; expected 'package:Struct[,Struct]'", arg)
+	}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if !token.IsIdentifier(name) {
+			return nil, errors.Errorf("Argument '%s' has invalid struct name '%s'", arg, name)
+		}
+		a.structs = append(a.structs, name)
+	}
+
+	return a, nil
+}
+
+// resolveDirectory returns the absolute path of the package's directory.  A
+// package which is not a directory is resolved as an import path.
+func resolveDirectory(l *loader.Loader, pkg string) (string, error) {
+	if fi, err := os.Stat(pkg); err == nil && fi.IsDir() {
+		return filepath.Abs(pkg)
+	}
+	if filepath.IsAbs(pkg) || strings.HasPrefix(pkg, ".") {
+		return "", errors.Errorf("Directory '%s' does not exist", pkg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	dir, err := l.FindImportDirectory(pkg, cwd)
+	if err != nil {
+		return "", errors.Errorf("Package '%s' is neither a directory nor an import path which can be found", pkg)
+	}
+	return dir, nil
+}
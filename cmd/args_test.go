@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ParseArgument(t *testing.T) {
+	tcs := []struct {
+		name     string
+		arg      string
+		expected *argument
+		err      bool
+	}{
+		{
+			name:     "Directory and struct",
+			arg:      "./foo:FooOptions",
+			expected: &argument{pkg: "./foo", structs: []string{"FooOptions"}},
+		},
+		{
+			name:     "Import path and structs",
+			arg:      "github.com/object88/hoarding:Options,Suboptions",
+			expected: &argument{pkg: "github.com/object88/hoarding", structs: []string{"Options", "Suboptions"}},
+		},
+		{
+			name:     "Directory only",
+			arg:      "./foo",
+			expected: &argument{pkg: "./foo"},
+		},
+		{
+			name:     "Pattern",
+			arg:      "./...",
+			expected: &argument{pkg: ".", recursive: true},
+		},
+		{
+			name:     "Import path pattern",
+			arg:      "github.com/object88/hoarding/...",
+			expected: &argument{pkg: "github.com/object88/hoarding", recursive: true},
+		},
+		{
+			name: "Empty",
+			arg:  "",
+			err:  true,
+		},
+		{
+			name: "Missing package",
+			arg:  ":FooOptions",
+			err:  true,
+		},
+		{
+			name: "Missing structs",
+			arg:  "./foo:",
+			err:  true,
+		},
+		{
+			name: "Invalid struct",
+			arg:  "./foo:FooOptions,,",
+			err:  true,
+		},
+		{
+			name: "Keyword struct",
+			arg:  "./foo:func",
+			err:  true,
+		},
+		{
+			name: "Pattern with structs",
+			arg:  "./...:FooOptions",
+			err:  true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseArgument(tc.arg)
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error from parseArgument")
+				} else {
+					t.Logf("Expected error from parseArgument: %s", err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error from parseArgument: %s", err.Error())
+			}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Errorf("Expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/object88/options/generate"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func createCheckCommand(rc *rootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "check reports generated files which are out of date, without writing them",
+		// A stale file is not a usage error.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rc.check(cmd.OutOrStdout(), args)
+		},
+	}
+}
+
+// check generates the options in memory, and writes a unified diff to `out`
+// for each generated file whose content differs from the file on disk.
+// Nothing is written when every file is up to date.
+func (rc *rootCommand) check(out io.Writer, args []string) error {
+	files, err := rc.generate(args)
+	if err != nil {
+		return err
+	}
+	// Nothing to check is more likely a mistaken argument than success.
+	if len(files) == 0 {
+		return errors.Errorf("No generated files to check; expected the arguments to name at least one struct")
+	}
+
+	fs := afero.NewOsFs()
+	stale := 0
+	for _, f := range files {
+		result, existing, err := generate.CompareFile(fs, f.Path, f.Content)
+		if err != nil {
+			return err
+		}
+		if result == generate.Unchanged {
+			continue
+		}
+		stale++
+
+		if _, err := out.Write(diff(f, existing, result)); err != nil {
+			return err
+		}
+	}
+
+	if stale != 0 {
+		return errors.Errorf("%d of %d generated files are out of date", stale, len(files))
+	}
+	return nil
+}
+
+// diff returns a unified diff from the existing content of the file to its
+// generated content, with paths relative to the working directory
+func diff(f *generate.File, existing []byte, result generate.WriteResult) []byte {
+	name := f.Path
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, f.Path); err == nil {
+			name = filepath.ToSlash(rel)
+		}
+	}
+
+	fromName := "a/" + name
+	if result == generate.Created {
+		fromName = "/dev/null"
+	}
+	return generate.Diff(fromName, "b/"+name, existing, f.Content)
+}
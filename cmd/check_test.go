@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/object88/options/generate"
+)
+
+const checkSource = "package foo\n\n// generate-options\ntype FooOptions struct {\n  a string\n}\n"
+
+func Test_Check(t *testing.T) {
+	tcs := []struct {
+		name     string
+		generate bool
+		edit     string
+		err      bool
+		expected []string
+	}{
+		{
+			name:     "Up to date",
+			generate: true,
+		},
+		{
+			name:     "Stale",
+			generate: true,
+			edit:     "package foo\n\n// generate-options\ntype FooOptions struct {\n  a string\n  b int\n}\n",
+			err:      true,
+			expected: []string{"--- a/", "+++ b/", "@@ ", "+func FooOptionsSetB(b int) options.Option {"},
+		},
+		{
+			name:     "Missing",
+			err:      true,
+			expected: []string{"--- /dev/null", "+++ b/", "@@ -0,0 +1,", "+func FooOptionsSetA(a string) options.Option {"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeCheckSource(t, checkSource)
+			defer os.RemoveAll(dir)
+
+			rc := newCheckCommand()
+			if tc.generate {
+				files, err := rc.generate([]string{dir})
+				if err != nil {
+					t.Fatalf("Failed to set up test; did not generate: %s", err.Error())
+				}
+				for _, f := range files {
+					if err := ioutil.WriteFile(f.Path, f.Content, 0644); err != nil {
+						t.Fatalf("Failed to set up test; did not write generated file: %s", err.Error())
+					}
+				}
+			}
+			if tc.edit != "" {
+				if err := ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(tc.edit), 0644); err != nil {
+					t.Fatalf("Failed to set up test; did not edit source: %s", err.Error())
+				}
+			}
+
+			var buf bytes.Buffer
+			err := rc.check(&buf, []string{dir})
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error from check")
+				} else {
+					t.Logf("Expected error from check: %s", err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error from check: %s", err.Error())
+			}
+
+			if len(tc.expected) == 0 && buf.Len() != 0 {
+				t.Errorf("Expected no output, got:\n%s", buf.String())
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("Expected output to contain '%s', got:\n%s", expected, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_Check_NoFiles(t *testing.T) {
+	// The package has a struct, but it is not marked for generation.
+	dir := writeCheckSource(t, "package foo\n\ntype FooOptions struct {\n  a string\n}\n")
+	defer os.RemoveAll(dir)
+
+	if err := newCheckCommand().check(ioutil.Discard, []string{dir}); err == nil {
+		t.Errorf("Expected error from check when nothing is generated")
+	}
+}
+
+// newCheckCommand returns a command with the default flags
+func newCheckCommand() *rootCommand {
+	return &rootCommand{
+		conflict:      generate.ErrorConflict.String(),
+		outputPattern: generate.DefaultOutputPattern,
+		prefix:        "Set",
+		style:         generate.StructStyle.String(),
+	}
+}
+
+// writeCheckSource writes the source as `foo.go` in a new temporary
+// directory, and returns the directory
+func writeCheckSource(t *testing.T, source string) string {
+	dir, err := ioutil.TempDir("", "check")
+	if err != nil {
+		t.Fatalf("Failed to set up test; did not create temporary dir: %s", err.Error())
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to set up test; did not write source: %s", err.Error())
+	}
+	return dir
+}
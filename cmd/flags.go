@@ -1,6 +1,15 @@
 package cmd
 
 const (
-	destinationKey string = "destination"
-	packageKey = "package"
-)
\ No newline at end of file
+	conflictKey      string = "conflict"
+	destinationKey          = "destination"
+	dryRunKey               = "dry-run"
+	excludeKey              = "exclude"
+	includeKey              = "include"
+	outputPatternKey        = "output-pattern"
+	packageKey              = "package"
+	prefixKey               = "prefix"
+	shimsKey                = "shims"
+	styleKey                = "style"
+	templateKey             = "template"
+)
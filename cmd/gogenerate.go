@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/object88/options/generate"
+	"github.com/object88/options/loader"
+	"github.com/pkg/errors"
+)
+
+// goGenerateEnv is the environment which `go generate` provides to the
+// commands it runs
+type goGenerateEnv struct {
+	file string
+	pkg  string
+	dir  string
+
+	// line is the line of the directive in the file, and argc is the number
+	// of arguments which the directive passes to the command
+	line int
+	argc int
+}
+
+// readGoGenerateEnv returns the `go generate` environment, if the command is
+// being run by `go generate`
+func readGoGenerateEnv() (*goGenerateEnv, bool) {
+	file := os.Getenv("GOFILE")
+	pkg := os.Getenv("GOPACKAGE")
+	if file == "" || pkg == "" {
+		return nil, false
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, false
+	}
+
+	// Without the line, other directives for the command cannot be found.
+	line, _ := strconv.Atoi(os.Getenv("GOLINE"))
+
+	return &goGenerateEnv{file: file, pkg: pkg, dir: dir, line: line, argc: len(os.Args) - 1}, true
+}
+
+// parseGoGenerateArgs loads only the package which `go generate` is running
+// in, and returns an argument for each struct name, or for each struct in
+// GOFILE marked with the directive if there are no names.  The output is
+// written beside GOFILE, and named after it.
+func parseGoGenerateArgs(l *loader.Loader, env *goGenerateEnv, outputPattern string, args []string) ([]generate.Arg, error) {
+	if err := l.LoadPackage(env.dir); err != nil {
+		return nil, err
+	}
+	l.Wait()
+
+	p, err := l.FindPackage(env.dir)
+	if err != nil {
+		return nil, err
+	}
+	if p.Name() != env.pkg {
+		return nil, errors.Errorf("Package in '%s' is '%s', but go generate is running in package '%s'", env.dir, p.Name(), env.pkg)
+	}
+
+	output := generate.OutputFilename(outputPattern, env.file)
+	if err := checkGoGenerateDirectives(env, output); err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 {
+		// Structs marked in other files are generated into their own outputs,
+		// by the directives in those files.
+		parsedArgs := []generate.Arg{}
+		for _, arg := range generate.Discover(l, env.dir, false) {
+			filename, _, err := p.FindSource(arg.StructName)
+			if err != nil {
+				return nil, err
+			}
+			if filepath.Base(filename) != env.file {
+				continue
+			}
+			arg.Output = output
+			parsedArgs = append(parsedArgs, arg)
+		}
+		return parsedArgs, nil
+	}
+
+	parsedArgs := make([]generate.Arg, len(args))
+	for k, arg := range args {
+		if !token.IsIdentifier(arg) {
+			return nil, errors.Errorf("Argument '%s' is not a struct name; with go generate, arguments name structs in package '%s'", arg, env.pkg)
+		}
+		parsedArgs[k] = generate.Arg{Source: env.dir, StructName: arg, Output: output}
+	}
+	return parsedArgs, nil
+}
+
+// goGenerateDirective is the prefix of a line which `go generate` runs
+const goGenerateDirective = "//go:generate "
+
+// checkGoGenerateDirectives reports whether the file has another directive
+// which runs the same command as the one being run.  Each would generate the
+// output named after the file, and the last to run would overwrite the
+// others.
+func checkGoGenerateDirectives(env *goGenerateEnv, output string) error {
+	src, err := ioutil.ReadFile(filepath.Join(env.dir, env.file))
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read '%s'", env.file)
+	}
+	lines := strings.Split(string(src), "\n")
+	if env.line < 1 || env.line > len(lines) {
+		return nil
+	}
+
+	// The command is what precedes the arguments on the directive's line.
+	words := directiveWords(lines[env.line-1])
+	if len(words) <= env.argc {
+		return nil
+	}
+	command := words[:len(words)-env.argc]
+
+	for k, line := range lines {
+		if k == env.line-1 || !hasWords(directiveWords(line), command) {
+			continue
+		}
+		first, second := k+1, env.line
+		if first > second {
+			first, second = second, first
+		}
+		return errors.Errorf("Lines %d and %d of '%s' both run '%s' under go generate, and would each write '%s'; name every struct in a single directive", first, second, env.file, strings.Join(command, " "), output)
+	}
+	return nil
+}
+
+// directiveWords returns the words of a `go generate` directive, or nothing
+// if the line is not one
+func directiveWords(line string) []string {
+	if !strings.HasPrefix(line, goGenerateDirective) {
+		return nil
+	}
+	return strings.Fields(strings.TrimPrefix(line, goGenerateDirective))
+}
+
+// hasWords reports whether the words start with the prefix
+func hasWords(words, prefix []string) bool {
+	if len(words) < len(prefix) {
+		return false
+	}
+	for k, word := range prefix {
+		if words[k] != word {
+			return false
+		}
+	}
+	return true
+}
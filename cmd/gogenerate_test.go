@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/object88/options/generate"
+	"github.com/object88/options/loader"
+	logtest "github.com/object88/options/log/testing"
+)
+
+func Test_ParseGoGenerateArgs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gogenerate")
+	if err != nil {
+		t.Fatalf("Failed to set up test; did not create temporary dir: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	// Both files have a marked struct, and each its own directive.
+	sources := map[string]string{
+		"foo.go": "package foo\n\n//go:generate options\n\n// generate-options\ntype FooOptions struct {\n  a string\n}\n",
+		"bar.go": "package foo\n\n//go:generate options\n\n// generate-options\ntype BarOptions struct {\n  b string\n}\n",
+	}
+	for filename, source := range sources {
+		if err := ioutil.WriteFile(filepath.Join(dir, filename), []byte(source), 0644); err != nil {
+			t.Fatalf("Failed to set up test; did not write source: %s", err.Error())
+		}
+	}
+
+	tcs := []struct {
+		name     string
+		file     string
+		args     []string
+		expected []generate.Arg
+	}{
+		{
+			name: "Discovered in file",
+			file: "foo.go",
+			expected: []generate.Arg{
+				{Source: dir, StructName: "FooOptions", Output: "foo_gen.go"},
+			},
+		},
+		{
+			name: "Discovered in other file",
+			file: "bar.go",
+			expected: []generate.Arg{
+				{Source: dir, StructName: "BarOptions", Output: "bar_gen.go"},
+			},
+		},
+		{
+			name: "Named",
+			file: "foo.go",
+			args: []string{"BarOptions"},
+			expected: []generate.Arg{
+				{Source: dir, StructName: "BarOptions", Output: "foo_gen.go"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			l := loader.NewLoader(logtest.NewLog(t))
+			env := &goGenerateEnv{file: tc.file, pkg: "foo", dir: dir, line: 3, argc: len(tc.args)}
+			actual, err := parseGoGenerateArgs(l, env, generate.DefaultOutputPattern, tc.args)
+			if err != nil {
+				t.Fatalf("Unexpected error from parseGoGenerateArgs: %s", err.Error())
+			}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Errorf("Expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_CheckGoGenerateDirectives(t *testing.T) {
+	tcs := []struct {
+		name   string
+		source string
+		line   int
+		argc   int
+		err    bool
+	}{
+		{
+			name:   "Single directive",
+			source: "package foo\n\n//go:generate options FooOptions BarOptions\n//go:generate stringer -type Kind\n",
+			line:   3,
+			argc:   2,
+		},
+		{
+			name:   "Several directives",
+			source: "package foo\n\n//go:generate options FooOptions\n//go:generate options BarOptions\n",
+			line:   4,
+			argc:   1,
+			err:    true,
+		},
+		{
+			name:   "Several directives with go run",
+			source: "package foo\n\n//go:generate go run github.com/object88/options/main\n//go:generate go run github.com/object88/options/main BarOptions\n",
+			line:   3,
+			argc:   0,
+			err:    true,
+		},
+		{
+			name:   "Other go run directive",
+			source: "package foo\n\n//go:generate go run github.com/object88/options/main FooOptions\n//go:generate go run golang.org/x/tools/cmd/stringer -type Kind\n",
+			line:   3,
+			argc:   1,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "gogenerate")
+			if err != nil {
+				t.Fatalf("Failed to set up test; did not create temporary dir: %s", err.Error())
+			}
+			defer os.RemoveAll(dir)
+			if err := ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(tc.source), 0644); err != nil {
+				t.Fatalf("Failed to set up test; did not write source: %s", err.Error())
+			}
+
+			env := &goGenerateEnv{file: "foo.go", pkg: "foo", dir: dir, line: tc.line, argc: tc.argc}
+			err = checkGoGenerateDirectives(env, "foo_gen.go")
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error from checkGoGenerateDirectives")
+				} else {
+					t.Logf("Expected error from checkGoGenerateDirectives: %s", err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error from checkGoGenerateDirectives: %s", err.Error())
+			}
+		})
+	}
+}
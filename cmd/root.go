@@ -3,13 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path"
-	"path/filepath"
-	"strings"
 
 	"github.com/object88/options/generate"
 	"github.com/object88/options/loader"
 	"github.com/object88/options/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
@@ -20,25 +19,35 @@ func InitializeCommands() *cobra.Command {
 	return rootCmd
 }
 
+// stdoutPattern is the output pattern which writes the generated code to
+// stdout instead of to files
+const stdoutPattern = "-"
+
 type rootCommand struct {
 	cobra.Command
 
-	destination string
-	packag      string
+	conflict      string
+	destination   string
+	dryRun        bool
+	exclude       []string
+	include       []string
+	outputPattern string
+	packag        string
+	prefix        string
+	shims         bool
+	style         string
+	template      string
 }
 
 func createRootCommand() *cobra.Command {
-	currentDirectory, err := os.Getwd()
-	if err != nil {
-		// Unknown why this could possibly happen.
-		return nil
-	}
-
 	var rc *rootCommand
 	rc = &rootCommand{
 		Command: cobra.Command{
-			Use:   "options",
-			Short: "options creates embeddable options structs",
+			Use:     "options",
+			Short:   "options creates embeddable options structs",
+			Version: generate.Version,
+			// Arguments name packages, not subcommands.
+			Args: cobra.ArbitraryArgs,
 			PreRunE: func(cmd *cobra.Command, args []string) error {
 				return rc.preexecute(cmd, args)
 			},
@@ -48,10 +57,22 @@ func createRootCommand() *cobra.Command {
 		},
 	}
 
-	flags := rc.Command.Flags()
+	flags := rc.Command.PersistentFlags()
+
+	flags.StringVar(&rc.conflict, conflictKey, generate.ErrorConflict.String(), "What to generate in place of a setter or constructor which is already declared by hand; one of 'error', 'skip', or 'rename'")
+	flags.StringVarP(&rc.destination, destinationKey, string(destinationKey[0]), "", "Destination directory for generated option setters, defaults to the directory of each struct")
+	flags.StringArrayVar(&rc.exclude, excludeKey, nil, "Glob patterns for the names of fields which get no setters, such as '*cache'")
+	flags.StringArrayVar(&rc.include, includeKey, nil, "Glob patterns for the names of fields which get setters; by default, every field does")
+	flags.StringVarP(&rc.outputPattern, outputPatternKey, "o", generate.DefaultOutputPattern, "Pattern for the names of generated files, where '{name}' is the name of the source file, or '-' to write the generated code to stdout")
+	flags.StringVarP(&rc.packag, packageKey, string(packageKey[0]), "", "Package for generated option setters, defaults to the package in, or the name of, the destination directory")
+	flags.StringVar(&rc.prefix, prefixKey, "Set", "Prefix for generated option setters, such as 'Set' or 'With'")
+	flags.BoolVar(&rc.shims, shimsKey, false, "Generate exported accessors for unexported fields, so that setters in another package can reach them")
+	flags.StringVar(&rc.template, templateKey, "", "Template file to generate the code with, instead of the bundled template")
+	flags.StringVar(&rc.style, styleKey, generate.StructStyle.String(), "Style of generated option setters; one of 'struct' (FooOptionsSetA), 'prefix' (SetA), or 'method' ((*FooOptions).SetA)")
 
-	flags.StringVarP(&rc.destination, destinationKey, string(destinationKey[0]), currentDirectory, "Destination for generated options")
-	flags.StringVar(&rc.packag, packageKey, string(packageKey[0]), "Package for generated options, defaults to same as destination directory")
+	rc.Command.Flags().BoolVar(&rc.dryRun, dryRunKey, false, "Print the files which would be written, and a diff against their existing content, without writing them")
+
+	rc.Command.AddCommand(createCheckCommand(rc))
 
 	return &rc.Command
 }
@@ -61,49 +82,128 @@ func (rc *rootCommand) preexecute(cmd *cobra.Command, args []string) error {
 }
 
 func (rc *rootCommand) execute(cmd *cobra.Command, args []string) error {
-	l := loader.NewLoader(log.Stdout())
-	g := generate.NewGenerator(l)
-
-	parsedArgs := make([]generate.Arg, len(args))
-	for k, arg := range args {
-		subs := strings.Split(arg, ":")
-		parsedArgs[k].Source = subs[0]
-		parsedArgs[k].StructName = subs[1]
+	files, err := rc.generate(args)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("Parsing %d struct\n", len(parsedArgs))
-
-	for _, parsedArg := range parsedArgs {
-		absPath, err := filepath.Abs(parsedArg.Source)
-		if err != nil {
-			return err
+	fs := afero.NewOsFs()
+	for _, f := range files {
+		switch {
+		case rc.outputPattern == stdoutPattern:
+			// Several files are written one after the other.
+			if _, err := os.Stdout.Write(f.Content); err != nil {
+				return err
+			}
+		case rc.dryRun:
+			result, existing, err := generate.CompareFile(fs, f.Path, f.Content)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s '%s' (dry run)\n", result, f.Path)
+			if _, err := os.Stdout.Write(diff(f, existing, result)); err != nil {
+				return err
+			}
+		default:
+			result, err := generate.WriteFile(fs, f.Path, f.Content)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s '%s'\n", result, f.Path)
 		}
-		l.LoadDirectory(absPath)
+	}
 
-		l.Wait()
+	return nil
+}
+
+// generate loads the packages named by the arguments, and generates the
+// options for their structs in memory
+func (rc *rootCommand) generate(args []string) ([]*generate.File, error) {
+	style, err := generate.ParseStyle(rc.style)
+	if err != nil {
+		return nil, err
+	}
+
+	conflict, err := generate.ParseConflict(rc.conflict)
+	if err != nil {
+		return nil, err
+	}
 
-		p, err := l.FindPackage(absPath)
+	outputPattern := rc.outputPattern
+	if outputPattern == stdoutPattern {
+		outputPattern = generate.DefaultOutputPattern
+	}
+
+	// Only the generated code, or what became of it, is written to stdout.
+	l := loader.NewLoader(log.Stderr())
+	g := generate.NewGenerator(l,
+		generate.SetStyle(style),
+		generate.SetPrefix(rc.prefix),
+		generate.SetConflict(conflict),
+		generate.SetDestination(rc.destination),
+		generate.SetPackage(rc.packag),
+		generate.SetShims(rc.shims),
+		generate.SetOutputPattern(outputPattern),
+		generate.SetTemplateFile(rc.template),
+		generate.SetInclude(rc.include...),
+		generate.SetExclude(rc.exclude...),
+	)
+
+	var parsedArgs []generate.Arg
+	if env, ok := readGoGenerateEnv(); ok {
+		parsedArgs, err = parseGoGenerateArgs(l, env, outputPattern, args)
+	} else {
+		parsedArgs, err = parseArgs(l, args)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GenerateFiles(parsedArgs...)
+}
+
+// parseArgs loads the packages named by the arguments, and returns an
+// argument for each struct to generate
+func parseArgs(l *loader.Loader, args []string) ([]generate.Arg, error) {
+	if len(args) == 0 {
+		return nil, errors.Errorf("No arguments; expected 'package:Struct[,Struct]' or 'package/...'")
+	}
+
+	parsedArgs := []generate.Arg{}
+	for _, arg := range args {
+		a, err := parseArgument(arg)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		filename, _, err := p.FindSource(parsedArg.StructName)
+		absPath, err := resolveDirectory(l, a.pkg)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		filename = filepath.Base(filename)
-		filename = strings.TrimSuffix(filename, filepath.Ext(filename))
-		absPath = path.Join(absPath, fmt.Sprintf("%s_gen.go", filename))
-		f, err := os.Create(absPath)
-		if err != nil {
-			return err
+		if a.recursive {
+			err = l.LoadDirectory(absPath)
+		} else {
+			err = l.LoadPackage(absPath)
 		}
-		fmt.Printf("target file: '%s'\n", absPath)
-		err = g.Generate(parsedArg, f)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		l.Wait()
+
+		for _, name := range a.structs {
+			parsedArgs = append(parsedArgs, generate.Arg{Source: absPath, StructName: name})
 		}
+		if len(a.structs) != 0 {
+			continue
+		}
+
+		// Without struct names, generate every struct marked with the directive.
+		discovered := generate.Discover(l, absPath, a.recursive)
+		if len(discovered) == 0 {
+			fmt.Fprintf(os.Stderr, "No structs marked with '// %s' in '%s'\n", generate.Directive, arg)
+		}
+		parsedArgs = append(parsedArgs, discovered...)
 	}
 
-	return nil
+	return parsedArgs, nil
 }
@@ -0,0 +1,114 @@
+package options
+
+import (
+	"reflect"
+)
+
+// ApplyEmbedded applies the option to the struct embedded within a target
+// which the option targets, searching through embedded structs and embedded
+// struct pointers by reflection.  Each of the embedded arguments is the
+// address of a struct embedded directly within the target, such as
+// `&foo.BarOptions`, or the address of an embedded struct pointer, such as
+// `&foo.BazOptions` for a `*BazOptions`.  An option which targets a struct
+// embedded more deeply is applied by the `Apply` of the directly embedded
+// struct which holds it.  Nil embedded pointers are allocated, and their
+// defaults applied, when the option is applied through them.
+func ApplyEmbedded(opt Option, embedded ...interface{}) error {
+	target := opt.TargetType()
+
+	var found reflect.Value
+	depth := -1
+	for _, e := range embedded {
+		v := reflect.ValueOf(e).Elem()
+		t := v.Type()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		d := 0
+		if t != target {
+			index, ok := findEmbedded(t, target)
+			if !ok {
+				continue
+			}
+			d = len(index)
+		}
+		if depth == -1 || d < depth {
+			found, depth = v, d
+		}
+	}
+	if depth == -1 {
+		return &ErrNoEmbeddedTarget{Target: target, Option: opt}
+	}
+
+	if found.Kind() == reflect.Ptr {
+		found = allocate(found)
+	} else {
+		found = found.Addr()
+	}
+
+	if depth != 0 {
+		// The embedded struct routes the option to its own embedded struct.
+		o, ok := found.Interface().(Optioner)
+		if !ok {
+			return &ErrNoEmbeddedTarget{Target: target, Option: opt}
+		}
+		return o.Apply(opt)
+	}
+	if err := opt.Apply(found.Interface()); err != nil {
+		return &ErrEmbeddedApply{Target: target, Option: opt, Err: err}
+	}
+	return nil
+}
+
+// findEmbedded returns the index sequence of the shallowest struct of type
+// target embedded within the struct type t.
+func findEmbedded(t, target reflect.Type) ([]int, bool) {
+	type candidate struct {
+		t     reflect.Type
+		index []int
+	}
+
+	visited := map[reflect.Type]bool{t: true}
+	queue := []candidate{{t: t}}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		for i := 0; i < c.t.NumField(); i++ {
+			sf := c.t.Field(i)
+			if !sf.Anonymous {
+				continue
+			}
+
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() != reflect.Struct || visited[ft] {
+				continue
+			}
+			visited[ft] = true
+
+			index := append(append([]int{}, c.index...), i)
+			if ft == target {
+				return index, true
+			}
+			queue = append(queue, candidate{t: ft, index: index})
+		}
+	}
+
+	return nil, false
+}
+
+// allocate sets a nil pointer value to a new instance of its element type,
+// with its defaults applied, and returns the pointer.
+func allocate(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+		if d, ok := v.Interface().(Defaulter); ok {
+			d.ApplyDefaults()
+		}
+	}
+	return v
+}
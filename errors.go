@@ -0,0 +1,68 @@
+package options
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ErrNoEmbeddedTarget is returned from `Optioner.Apply` when an option
+// targets a type which is neither the optioner nor any of its embedded
+// structs.
+type ErrNoEmbeddedTarget struct {
+	Target reflect.Type
+	Option Option
+}
+
+func (e *ErrNoEmbeddedTarget) Error() string {
+	return fmt.Sprintf("No embedded target of type '%s' for option %T", e.Target, e.Option)
+}
+
+// Is reports whether the target is an `*ErrNoEmbeddedTarget`, so that a zero
+// value may be used with `errors.Is`.
+func (e *ErrNoEmbeddedTarget) Is(target error) bool {
+	_, ok := target.(*ErrNoEmbeddedTarget)
+	return ok
+}
+
+// ErrTargetMismatch is returned from `Option.Apply` when the target is not a
+// pointer to the option's target type.  `Target` is the type of the target
+// which was supplied.
+type ErrTargetMismatch struct {
+	Target reflect.Type
+	Option Option
+}
+
+func (e *ErrTargetMismatch) Error() string {
+	return fmt.Sprintf("Target of type '%s' does not match option %T, which requires '*%s'", e.Target, e.Option, e.Option.TargetType())
+}
+
+// Is reports whether the target is an `*ErrTargetMismatch`, so that a zero
+// value may be used with `errors.Is`.
+func (e *ErrTargetMismatch) Is(target error) bool {
+	_, ok := target.(*ErrTargetMismatch)
+	return ok
+}
+
+// ErrEmbeddedApply is returned from `Optioner.Apply` when an embedded
+// struct fails to apply an option.  It wraps the embedded struct's error.
+type ErrEmbeddedApply struct {
+	Target reflect.Type
+	Option Option
+	Err    error
+}
+
+func (e *ErrEmbeddedApply) Error() string {
+	return fmt.Sprintf("Embedded target of type '%s' failed to apply option %T: %s", e.Target, e.Option, e.Err)
+}
+
+// Is reports whether the target is an `*ErrEmbeddedApply`, so that a zero
+// value may be used with `errors.Is`.
+func (e *ErrEmbeddedApply) Is(target error) bool {
+	_, ok := target.(*ErrEmbeddedApply)
+	return ok
+}
+
+// Unwrap returns the embedded struct's error
+func (e *ErrEmbeddedApply) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/object88/options/loader"
+	"github.com/pkg/errors"
+)
+
+// annotateSourceError rewrites errors positioned within generated source so
+// that each includes the offending line
+func annotateSourceError(err error, src []byte) error {
+	type positioned struct {
+		pos token.Position
+		msg string
+	}
+
+	var errs []positioned
+	switch e := err.(type) {
+	case scanner.ErrorList:
+		for _, se := range e {
+			errs = append(errs, positioned{pos: se.Pos, msg: se.Msg})
+		}
+	case loader.FileErrors:
+		for _, fe := range e {
+			errs = append(errs, positioned{pos: fe.Position, msg: fe.Message})
+		}
+	default:
+		return err
+	}
+
+	lines := bytes.Split(src, []byte("\n"))
+	var sb strings.Builder
+	for k, e := range errs {
+		if k != 0 {
+			sb.WriteRune('\n')
+		}
+		fmt.Fprintf(&sb, "%d:%d: %s", e.pos.Line, e.pos.Column, e.msg)
+		if e.pos.Line > 0 && e.pos.Line <= len(lines) {
+			fmt.Fprintf(&sb, "\n\t%s", strings.TrimSpace(string(lines[e.pos.Line-1])))
+		}
+	}
+	return errors.New(sb.String())
+}
+
+// annotateTemplateError appends the offending line of the template to an
+// error from parsing or executing it
+func annotateTemplateError(err error, name string, tmplSrc []byte) error {
+	re := regexp.MustCompile(regexp.QuoteMeta(name) + `:(\d+)`)
+	m := re.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+
+	line, _ := strconv.Atoi(m[1])
+	lines := bytes.Split(tmplSrc, []byte("\n"))
+	if line <= 0 || line > len(lines) {
+		return err
+	}
+	return errors.Errorf("%s\n\t%s", err.Error(), strings.TrimSpace(string(lines[line-1])))
+}
@@ -0,0 +1,40 @@
+package generate
+
+import (
+	"go/types"
+
+	"github.com/object88/options/templates"
+)
+
+const (
+	// appendPrefix is the prefix of the setters which append to slices
+	appendPrefix = "Append"
+
+	// putPrefix is the prefix of the setters which add entries to maps
+	putPrefix = "Put"
+
+	// deletePrefix is the prefix of the setters which remove entries from maps
+	deletePrefix = "Delete"
+)
+
+// collectionSetters adds the names and types of the setters which modify a
+// slice or map field in place, so that options from several sources can be
+// layered.  Setters generated in another package cannot reach the field
+// through a shim, so unexported fields only get the replacing setter there.
+// The element and key types are written with `typeString`.
+func (g *Generator) collectionSetters(fd *templates.FuncData, structName, capitalized string, f *types.Var, split bool, typeString func(types.Type) string) {
+	if split && !f.Exported() {
+		return
+	}
+
+	switch u := f.Type().Underlying().(type) {
+	case *types.Slice:
+		fd.AppendName = g.style.setterName(structName, appendPrefix, capitalized)
+		fd.ElemType = typeString(u.Elem())
+	case *types.Map:
+		fd.PutName = g.style.setterName(structName, putPrefix, capitalized)
+		fd.DeleteName = g.style.setterName(structName, deletePrefix, capitalized)
+		fd.KeyType = typeString(u.Key())
+		fd.ElemType = typeString(u.Elem())
+	}
+}
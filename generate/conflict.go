@@ -0,0 +1,148 @@
+package generate
+
+import (
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Conflict describes what is generated in place of a setter or constructor
+// whose name is already declared by hand
+type Conflict int
+
+const (
+	// ErrorConflict fails to generate the options
+	ErrorConflict Conflict = iota
+
+	// SkipConflict generates nothing in place of the declaration, and leaves
+	// the hand-written one to do its job
+	SkipConflict
+
+	// RenameConflict generates the declaration with a numbered name, such as
+	// `FooOptionsSetA2`
+	RenameConflict
+)
+
+var conflictNames = map[Conflict]string{
+	ErrorConflict:  "error",
+	SkipConflict:   "skip",
+	RenameConflict: "rename",
+}
+
+// ParseConflict returns the Conflict for the provided name
+func ParseConflict(name string) (Conflict, error) {
+	for c, n := range conflictNames {
+		if strings.EqualFold(n, name) {
+			return c, nil
+		}
+	}
+	return ErrorConflict, errors.Errorf("Unknown conflict policy '%s'; expected one of 'error', 'skip', or 'rename'", name)
+}
+
+// String is the implementation of fmt.Stringer
+func (c Conflict) String() string {
+	return conflictNames[c]
+}
+
+// inspect finds the declarations in the packages of the outputs which are
+// written by hand, ignoring the files which are about to be generated, so
+// that stale generated code is not mistaken for a conflict.  The outputs
+// generated into the same package share the names they declare, and the
+// names of the structs' option types are chosen among them.
+func (g *Generator) inspect(outputs []*output) {
+	byDir := map[string][]string{}
+	for _, out := range outputs {
+		dir := filepath.Dir(out.path)
+		byDir[dir] = append(byDir[dir], filepath.Base(out.path))
+	}
+
+	inspected := map[string]*types.Package{}
+	declared := map[string]*namer{}
+	for dir, filenames := range byDir {
+		declared[dir] = newNamer()
+		p, err := g.l.FindPackage(dir)
+		if err != nil || p.Types() == nil {
+			// The destination of external setters is not always a loaded
+			// package.
+			continue
+		}
+		inspected[dir] = p.Inspect(filenames...)
+		declared[dir].reserve(inspected[dir].Scope().Names()...)
+	}
+
+	for _, out := range outputs {
+		out.declared = declared[filepath.Dir(out.path)]
+		for _, t := range out.targets {
+			if t.optName == "" {
+				own := declared[t.pkg.AbsPath]
+				t.optName = own.name(createOptName(t.name))
+				own.reserve(t.optName)
+			}
+			t.user = t.named
+			if pkg, ok := inspected[t.pkg.AbsPath]; ok {
+				if n, ok := userNamed(pkg, t.name); ok {
+					t.user = n
+				}
+			}
+		}
+	}
+}
+
+// userNamed returns the named type declared in the inspected package
+func userNamed(pkg *types.Package, name string) (*types.Named, bool) {
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, false
+	}
+	n, ok := obj.Type().(*types.Named)
+	return n, ok
+}
+
+// checkMethods reports whether the struct already has a hand-written method
+// with the name of one of its generated methods, such as `Apply`.  Options
+// are applied and inspected through them, so they cannot be skipped or
+// renamed.
+func checkMethods(t *target) error {
+	for i := 0; i < t.user.NumMethods(); i++ {
+		name := t.user.Method(i).Name()
+		for _, generated := range t.generatedMethods() {
+			if name == generated {
+				return errors.Errorf("Struct '%s' already has a method '%s', which is generated; remove it, or rename it", t.name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// declaredNamer returns a namer for the setters of the struct, which must not
+// collide with the hand-written declarations where they are generated, nor
+// with the setters generated there for other structs
+func (g *Generator) declaredNamer(t *target, out *output) *namer {
+	if g.style == MethodStyle {
+		return memberNamer(t)
+	}
+	return out.declared
+}
+
+// resolveConflict returns the name with which a setter or constructor is
+// generated, according to the conflict policy.  An empty name means that
+// nothing is generated.
+func (g *Generator) resolveConflict(declared *namer, structName, name string) (string, error) {
+	if declared.free(name) {
+		declared.reserve(name)
+		return name, nil
+	}
+
+	switch g.conflict {
+	case SkipConflict:
+		return "", nil
+	case RenameConflict:
+		renamed := declared.name(name)
+		declared.reserve(renamed)
+		return renamed, nil
+	default:
+		return "", errors.Errorf("Options for '%s' cannot declare '%s', which is already declared; remove it, or skip or rename conflicting declarations", structName, name)
+	}
+}
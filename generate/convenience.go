@@ -0,0 +1,90 @@
+package generate
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/object88/options/templates"
+	"github.com/pkg/errors"
+)
+
+const (
+	// enablePrefix is the prefix of the setters which set bools to true
+	enablePrefix = "Enable"
+
+	// disablePrefix is the prefix of the setters which set bools to false
+	disablePrefix = "Disable"
+
+	// fromStringSuffix is the suffix of the setters which parse a string
+	fromStringSuffix = "FromString"
+)
+
+// convenienceSetters adds the names of the setters which enable and disable
+// bool fields, and which parse durations, numbers and sizes from strings
+func (g *Generator) convenienceSetters(fd *templates.FuncData, structName string, sf setterField) error {
+	t := sf.v.Type()
+	if sf.size && (!isInteger(t) || isDuration(t)) {
+		return errors.Errorf("Field '%s.%s' has the '%s' option, but is not an integer", structName, sf.v.Name(), sizeOption)
+	}
+
+	if b, ok := t.Underlying().(*types.Basic); ok && b.Info()&types.IsBoolean != 0 {
+		fd.EnableName = g.style.setterName(structName, enablePrefix, sf.name)
+		fd.DisableName = g.style.setterName(structName, disablePrefix, sf.name)
+	}
+	if _, _, ok := stringParser(t, sf.size); ok {
+		fd.FromStringName = g.style.setterName(structName, g.prefix, sf.name+fromStringSuffix)
+	}
+	return nil
+}
+
+// stringParser returns the call of the helper in the options package which
+// parses a string for a field of type `t`, with a verb for the string, and
+// whether the helper returns `t` itself rather than a value which must be
+// converted
+func stringParser(t types.Type, size bool) (string, bool, bool) {
+	if isDuration(t) {
+		return "options.ParseDuration(%s)", true, true
+	}
+
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return "", false, false
+	}
+	bits := parseBits(b)
+	info := b.Info()
+	var helper string
+	var result types.BasicKind
+	switch {
+	case size && info&types.IsUnsigned != 0:
+		helper, result = "ParseByteSize", types.Uint64
+	case size && info&types.IsInteger != 0:
+		helper, result = "ParseByteSizeInt", types.Int64
+	case info&types.IsUnsigned != 0:
+		helper, result = "ParseUint", types.Uint64
+	case info&types.IsInteger != 0:
+		helper, result = "ParseInt", types.Int64
+	case info&types.IsFloat != 0:
+		helper, result = "ParseFloat", types.Float64
+	default:
+		return "", false, false
+	}
+
+	exact := t == types.Typ[result]
+	return fmt.Sprintf("options.%s(%%s, %d)", helper, bits), exact, true
+}
+
+// parseBits returns the bit size with which a basic numeric type is parsed,
+// where zero is the size of `int` or `uint` on the platform
+func parseBits(b *types.Basic) int {
+	switch b.Kind() {
+	case types.Int, types.Uint, types.Uintptr:
+		return 0
+	}
+	return basicBits(b)
+}
+
+// isInteger reports whether the underlying type of `t` is an integer
+func isInteger(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsInteger != 0
+}
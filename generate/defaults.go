@@ -0,0 +1,118 @@
+package generate
+
+import (
+	"go/types"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/object88/options/templates"
+	"github.com/pkg/errors"
+)
+
+// defaultTag is the struct tag which holds a field's default value
+const defaultTag = "default"
+
+// defaultLiteral converts the value of a field's `default` tag to a Go
+// literal which may be assigned to a field of type `t`.  Durations are
+// written as nanoseconds so that the generated code does not need to import
+// `time`.
+func defaultLiteral(t types.Type, value string) (string, error) {
+	if isDuration(t) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(int64(d), 10), nil
+	}
+
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return "", errors.Errorf("Defaults are not supported for type '%s'", t.String())
+	}
+
+	info := b.Info()
+	switch {
+	case info&types.IsString != 0:
+		return strconv.Quote(value), nil
+	case info&types.IsBoolean != 0:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(v), nil
+	case info&types.IsUnsigned != 0:
+		v, err := strconv.ParseUint(value, 0, basicBits(b))
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatUint(v, 10), nil
+	case info&types.IsInteger != 0:
+		v, err := strconv.ParseInt(value, 0, basicBits(b))
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(v, 10), nil
+	case info&types.IsFloat != 0:
+		v, err := strconv.ParseFloat(value, basicBits(b))
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(v, 'g', -1, basicBits(b)), nil
+	}
+
+	return "", errors.Errorf("Defaults are not supported for type '%s'", t.String())
+}
+
+// isDuration reports whether `t` is `time.Duration`
+func isDuration(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == "time" && n.Obj().Name() == "Duration"
+}
+
+// basicBits returns the size in bits of a basic numeric type
+func basicBits(b *types.Basic) int {
+	switch b.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	}
+	return 64
+}
+
+// fieldDefault returns the literal for the field's `default` tag, if it has
+// one
+func fieldDefault(f *types.Var, tag string) (string, bool, error) {
+	value, ok := reflect.StructTag(tag).Lookup(defaultTag)
+	if !ok {
+		return "", false, nil
+	}
+	literal, err := defaultLiteral(f.Type(), value)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "Invalid default '%s' for field '%s'", value, f.Name())
+	}
+	return literal, true, nil
+}
+
+// structDefaults returns the defaults of every field of the struct, whether
+// or not it gets a setter.  Embedded options structs set their own defaults.
+func structDefaults(pkg *types.Package, structName string, s *types.Struct) ([]templates.DefaultData, error) {
+	defaults := []templates.DefaultData{}
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if n, ok := derefNamed(f.Type()); ok && f.Anonymous() && isOptioner(pkg, n) {
+			continue
+		}
+		value, ok, err := fieldDefault(f, s.Tag(i))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to process struct '%s'", structName)
+		}
+		if ok {
+			defaults = append(defaults, templates.DefaultData{OptionName: f.Name(), Value: value})
+		}
+	}
+	return defaults, nil
+}
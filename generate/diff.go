@@ -0,0 +1,239 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// edit is a single line of a diff; unchanged, removed or added
+type edit struct {
+	kind byte
+	line string
+	from int
+	to   int
+}
+
+// Diff returns a unified diff from the content `from` to the content `to`,
+// or nil if they are the same
+func Diff(fromName, toName string, from, to []byte) []byte {
+	if bytes.Equal(from, to) {
+		return nil
+	}
+
+	edits := diffLines(splitLines(from), splitLines(to))
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", fromName, toName)
+
+	k := 0
+	for k < len(edits) {
+		for k < len(edits) && edits[k].kind == ' ' {
+			k++
+		}
+		if k == len(edits) {
+			break
+		}
+
+		// Changes separated by no more than twice the context share a hunk.
+		start := k - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := k
+		for {
+			for end < len(edits) && edits[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(edits) && edits[next].kind == ' ' {
+				next++
+			}
+			if next < len(edits) && next-end <= 2*diffContext {
+				end = next
+				continue
+			}
+			end += diffContext
+			if end > len(edits) {
+				end = len(edits)
+			}
+			break
+		}
+
+		writeHunk(&buf, edits[start:end])
+		k = end
+	}
+
+	return buf.Bytes()
+}
+
+// writeHunk writes the edits as a single hunk of a unified diff
+func writeHunk(buf *bytes.Buffer, edits []edit) {
+	fromCount, toCount := 0, 0
+	for _, e := range edits {
+		if e.kind != '+' {
+			fromCount++
+		}
+		if e.kind != '-' {
+			toCount++
+		}
+	}
+
+	fromStart, toStart := edits[0].from, edits[0].to
+	if fromCount != 0 {
+		fromStart++
+	}
+	if toCount != 0 {
+		toStart++
+	}
+
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", fromStart, fromCount, toStart, toCount)
+	for _, e := range edits {
+		buf.WriteByte(e.kind)
+		buf.WriteString(e.line)
+		buf.WriteByte('\n')
+	}
+}
+
+// diffLines returns the edits which turn the lines `a` into the lines `b`,
+// using Myers' algorithm, which needs space linear in their number
+func diffLines(a, b []string) []edit {
+	d := &differ{a: a, b: b, edits: make([]edit, 0, len(a)+len(b))}
+	d.diff(0, len(a), 0, len(b))
+	return d.edits
+}
+
+// differ collects the edits between the lines `a` and `b`
+type differ struct {
+	a     []string
+	b     []string
+	edits []edit
+}
+
+// diff appends the edits which turn `a[aLo:aHi]` into `b[bLo:bHi]`.  Lines
+// which they start and end with are unchanged; the rest is divided at the
+// middle of a shortest edit script, and each half is diffed in turn.
+func (d *differ) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.edits = append(d.edits, edit{kind: ' ', line: d.a[aLo], from: aLo, to: bLo})
+		aLo++
+		bLo++
+	}
+	aEnd, bEnd := aHi, bHi
+	for aLo < aEnd && bLo < bEnd && d.a[aEnd-1] == d.b[bEnd-1] {
+		aEnd--
+		bEnd--
+	}
+
+	if x, y, ok := d.bisect(aLo, aEnd, bLo, bEnd); ok {
+		d.diff(aLo, x, bLo, y)
+		d.diff(x, aEnd, y, bEnd)
+	} else {
+		for i := aLo; i < aEnd; i++ {
+			d.edits = append(d.edits, edit{kind: '-', line: d.a[i], from: i, to: bLo})
+		}
+		for j := bLo; j < bEnd; j++ {
+			d.edits = append(d.edits, edit{kind: '+', line: d.b[j], from: aEnd, to: j})
+		}
+	}
+
+	for i := aEnd; i < aHi; i++ {
+		d.edits = append(d.edits, edit{kind: ' ', line: d.a[i], from: i, to: i - aEnd + bEnd})
+	}
+}
+
+// bisect finds where the paths of a shortest edit script from `a[aLo:aHi]`
+// to `b[bLo:bHi]`, searched forward from their starts and backward from
+// their ends, overlap.  It reports false when the lines have nothing in
+// common, so that they are simply replaced.
+func (d *differ) bisect(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	forward := make([]int, 2*maxD+2)
+	backward := make([]int, 2*maxD+2)
+	for k := range forward {
+		forward[k] = -1
+		backward[k] = -1
+	}
+	forward[offset+1] = 0
+	backward[offset+1] = 0
+
+	delta := n - m
+	// When the difference in lengths is odd, the paths can only overlap on a
+	// forward step.
+	odd := delta%2 != 0
+	kStart, kEnd, rStart, rEnd := 0, 0, 0, 0
+	for step := 0; step < maxD; step++ {
+		for k := -step + kStart; k <= step-kEnd; k += 2 {
+			var x int
+			if k == -step || k != step && forward[offset+k-1] < forward[offset+k+1] {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			switch {
+			case x > n:
+				kEnd += 2
+			case y > m:
+				kStart += 2
+			case odd:
+				r := offset + delta - k
+				if r >= 0 && r < len(backward) && backward[r] != -1 && x >= n-backward[r] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+
+		for k := -step + rStart; k <= step-rEnd; k += 2 {
+			var x int
+			if k == -step || k != step && backward[offset+k-1] < backward[offset+k+1] {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aHi-x-1] == d.b[bHi-y-1] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+			switch {
+			case x > n:
+				rEnd += 2
+			case y > m:
+				rStart += 2
+			case !odd:
+				f := offset + delta - k
+				if f >= 0 && f < len(forward) && forward[f] != -1 {
+					fx := forward[f]
+					if fx >= n-x {
+						return aLo + fx, bLo + fx - (f - offset), true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// splitLines splits the content into lines, without their line endings
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
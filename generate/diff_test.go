@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Diff(t *testing.T) {
+	tcs := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "Same",
+			from:     "a\nb\n",
+			to:       "a\nb\n",
+			expected: "",
+		},
+		{
+			name:     "Created",
+			from:     "",
+			to:       "a\nb\n",
+			expected: "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:     "Changed",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			to:       "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			expected: "--- from\n+++ to\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:     "Separate hunks",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:       "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			expected: "--- from\n+++ to\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,3 @@\n 9\n 10\n 11\n-12\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := string(Diff("from", "to", []byte(tc.from), []byte(tc.to)))
+			if actual != tc.expected {
+				t.Errorf("Expected diff:\n%s\ngot:\n%s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_Diff_Large(t *testing.T) {
+	// A table of every pair of lines would need billions of entries.
+	lines := make([]string, 50000)
+	for k := range lines {
+		lines[k] = fmt.Sprintf("line %d", k)
+	}
+	from := strings.Join(lines, "\n") + "\n"
+	lines[25000] = "changed"
+	to := strings.Join(lines, "\n") + "\n"
+
+	expected := "--- from\n+++ to\n@@ -24998,7 +24998,7 @@\n line 24997\n line 24998\n line 24999\n-line 25000\n+changed\n line 25001\n line 25002\n line 25003\n"
+	actual := string(Diff("from", "to", []byte(from), []byte(to)))
+	if actual != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, actual)
+	}
+}
@@ -0,0 +1,50 @@
+package generate
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/object88/options/loader"
+)
+
+// Directive is the line in a type's doc comment which marks it for
+// generation
+const Directive = "generate-options"
+
+// Discover returns an argument for each struct marked with the directive in
+// the loaded package at the path, or, if recursive, in any loaded package
+// beneath it.  Packages imported from elsewhere, including vendored
+// packages, are not searched.
+func Discover(l *loader.Loader, absPath string, recursive bool) []Arg {
+	args := []Arg{}
+	for _, p := range l.Packages() {
+		if !within(absPath, p.AbsPath, recursive) {
+			continue
+		}
+		for _, name := range p.FindDirective(Directive) {
+			args = append(args, Arg{Source: p.AbsPath, StructName: name})
+		}
+	}
+	return args
+}
+
+// within reports whether the package path is the root, or, if recursive, a
+// non-vendored directory beneath it
+func within(root, path string, recursive bool) bool {
+	if path == root {
+		return true
+	}
+	if !recursive {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(rel), "/") {
+		if elem == "vendor" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,15 @@
+package generate
+
+// Dispatch describes how the generated `Apply` routes an option to the
+// embedded struct which it targets
+type Dispatch int
+
+const (
+	// StaticDispatch generates a switch over the `reflect.Type` of each
+	// embedded struct, resolved once at package initialization
+	StaticDispatch Dispatch = iota
+
+	// ReflectDispatch looks up the embedded struct and its `Apply` method by
+	// reflection each time an option is applied
+	ReflectDispatch
+)
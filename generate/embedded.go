@@ -0,0 +1,111 @@
+package generate
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/object88/options/templates"
+)
+
+// embeddedOptioner is an options struct reachable from the generated struct
+// through a chain of embedded fields
+type embeddedOptioner struct {
+	named *types.Named
+	path  []*types.Var
+	index []int
+}
+
+// collectEmbedded walks the embedded fields of `s`, breadth first, and
+// returns every options struct reachable through them.  When a type is
+// reachable by more than one chain, only the shallowest is kept.
+func collectEmbedded(pkg *types.Package, s *types.Struct) []embeddedOptioner {
+	type candidate struct {
+		s     *types.Struct
+		path  []*types.Var
+		index []int
+	}
+
+	results := []embeddedOptioner{}
+	visited := map[*types.Named]bool{}
+	queue := []candidate{{s: s}}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		for i := 0; i < c.s.NumFields(); i++ {
+			f := c.s.Field(i)
+			if !f.Anonymous() {
+				continue
+			}
+			n, ok := derefNamed(f.Type())
+			if !ok || visited[n] || !isOptioner(pkg, n) {
+				continue
+			}
+			visited[n] = true
+
+			e := embeddedOptioner{
+				named: n,
+				path:  append(append([]*types.Var{}, c.path...), f),
+				index: append(append([]int{}, c.index...), i),
+			}
+			results = append(results, e)
+			queue = append(queue, candidate{s: n.Underlying().(*types.Struct), path: e.path, index: e.index})
+		}
+	}
+
+	return results
+}
+
+// derefNamed returns the named type of `t`, looking through a pointer
+func derefNamed(t types.Type) (*types.Named, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := t.(*types.Named)
+	return n, ok
+}
+
+// hasGenerated reports whether the named options struct has, or will have,
+// the generated method.  Structs in the same package are expected to be
+// generated alongside the embedding struct.
+func hasGenerated(pkg *types.Package, n *types.Named, method string) bool {
+	return n.Obj().Pkg() == pkg || hasMethod(n, method)
+}
+
+// templateData converts the embedded options struct for use by the
+// template.  Type names and variables are only needed, and only imported,
+// for static dispatch.
+func (e embeddedOptioner) templateData(pkg *types.Package, imps *imports, tvs *typeVars, structName string, static bool) templates.EmbeddedData {
+	selectors := make([]string, len(e.path))
+
+	ed := templates.EmbeddedData{}
+	for k, f := range e.path {
+		selectors[k] = f.Name()
+
+		if _, ok := f.Type().(*types.Pointer); ok {
+			ed.Pointer = k == len(e.path)-1
+			if !static {
+				continue
+			}
+			n, _ := derefNamed(f.Type())
+			ed.Pointers = append(ed.Pointers, templates.EmbeddedPointer{
+				Selector: strings.Join(selectors[:k+1], "."),
+				TypeName: imps.typeString(n),
+				Defaults: hasGenerated(pkg, n, "ApplyDefaults"),
+			})
+		}
+	}
+
+	// Only directly embedded structs are validated and defaulted; they handle
+	// their own embedded structs in turn.
+	ed.Direct = len(e.path) == 1
+	ed.Validate = ed.Direct && hasGenerated(pkg, e.named, "Validate")
+	ed.Defaults = ed.Direct && hasGenerated(pkg, e.named, "ApplyDefaults")
+
+	ed.Selector = strings.Join(selectors, ".")
+	if static {
+		ed.TypeName = imps.typeString(e.named)
+		ed.TypeVar = tvs.add(createTypeVar(structName+strings.Join(selectors, "")), ed.TypeName)
+	}
+	return ed
+}
@@ -0,0 +1,179 @@
+package generate
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/object88/options/loader"
+	"github.com/pkg/errors"
+)
+
+// target is a struct for which options are generated
+type target struct {
+	pkg      *loader.Package
+	name     string
+	filename string
+	named    *types.Named
+	s        *types.Struct
+
+	// user is the struct as declared without any previously generated code,
+	// and has only its hand-written methods
+	user *types.Named
+
+	// presence is the field which records the fields set by options, if the
+	// struct tracks them
+	presence *types.Var
+
+	// optName is the name of the type of the struct's options, which is
+	// declared beside it
+	optName string
+}
+
+// output describes a generated file, the structs it holds the generated code
+// of, and which parts of it.  The code is split between two outputs when the
+// setters are generated into another package.
+type output struct {
+	path       string
+	importPath string
+	pkgName    string
+	setters    bool
+	methods    bool
+	split      bool
+	targets    []*target
+
+	// declared holds the names declared in the output's package, by hand or
+	// by any output generated into it, and is shared by those outputs
+	declared *namer
+}
+
+// hasTarget reports whether the output already holds the struct
+func (o *output) hasTarget(t *target) bool {
+	for _, existing := range o.targets {
+		if existing.named == t.named {
+			return true
+		}
+	}
+	return false
+}
+
+// sortTargets orders the structs of the output by their declarations, so
+// that the generated code does not depend on the order of the arguments
+func (o *output) sortTargets() {
+	sort.SliceStable(o.targets, func(a, b int) bool {
+		ta, tb := o.targets[a], o.targets[b]
+		if ta.filename != tb.filename {
+			return ta.filename < tb.filename
+		}
+		return ta.named.Obj().Pos() < tb.named.Obj().Pos()
+	})
+}
+
+// destinationDir returns the absolute path of the directory into which the
+// setters are generated
+func (g *Generator) destinationDir(p *loader.Package) (string, error) {
+	if g.destination == "" {
+		return p.AbsPath, nil
+	}
+	dest, err := filepath.Abs(g.destination)
+	if err != nil {
+		return "", errors.Wrapf(err, "Could not get absolute path for destination '%s'", g.destination)
+	}
+	return dest, nil
+}
+
+// destinationPackage returns the name of the package into which the setters
+// are generated
+func (g *Generator) destinationPackage(dest string) string {
+	if g.packageName != "" {
+		return g.packageName
+	}
+	if name, ok := g.l.FindDirectoryPackageName(dest); ok {
+		return name
+	}
+	return strings.Replace(filepath.Base(dest), "-", "_", -1)
+}
+
+// checkExternal reports whether the setters for the struct can be generated
+// into another package.  The struct and the types of its fields must be
+// exported, and unexported fields must be reached through shims.  Fields
+// without setters are ignored.
+func (g *Generator) checkExternal(pkg *types.Package, structName string, s *types.Struct, out *output) error {
+	if g.style == MethodStyle {
+		return errors.Errorf("Setters for '%s' cannot be methods when generated into package '%s'", structName, out.pkgName)
+	}
+	if out.importPath == pkg.Path() {
+		return errors.Errorf("Destination for '%s' is the directory of package '%s'", structName, pkg.Path())
+	}
+	if !ast.IsExported(structName) {
+		return errors.Errorf("Struct '%s' is unexported, and cannot be referenced from package '%s'", structName, out.pkgName)
+	}
+
+	fields, err := g.setterFields(pkg, structName, s)
+	if err != nil {
+		return err
+	}
+
+	unexported := []string{}
+	for _, sf := range fields {
+		f := sf.v
+		if name, ok := unexportedType(pkg, f.Type()); ok {
+			return errors.Errorf("Field '%s.%s' has unexported type '%s', which cannot be referenced from package '%s'", structName, f.Name(), name, out.pkgName)
+		}
+		if !f.Exported() {
+			unexported = append(unexported, f.Name())
+		}
+	}
+
+	if len(unexported) != 0 && !g.shims {
+		return errors.Errorf("Struct '%s' has unexported fields '%s', which cannot be set from package '%s'; export them, or generate accessor shims in package '%s'", structName, strings.Join(unexported, "', '"), out.pkgName, pkg.Name())
+	}
+	return nil
+}
+
+// unexportedType returns the name of an unexported type from the package
+// which is part of `t`
+func unexportedType(pkg *types.Package, t types.Type) (string, bool) {
+	switch t := t.(type) {
+	case *types.Named:
+		if t.Obj().Pkg() == pkg && !t.Obj().Exported() {
+			return t.Obj().Name(), true
+		}
+	case *types.Pointer:
+		return unexportedType(pkg, t.Elem())
+	case *types.Slice:
+		return unexportedType(pkg, t.Elem())
+	case *types.Array:
+		return unexportedType(pkg, t.Elem())
+	case *types.Chan:
+		return unexportedType(pkg, t.Elem())
+	case *types.Map:
+		if name, ok := unexportedType(pkg, t.Key()); ok {
+			return name, true
+		}
+		return unexportedType(pkg, t.Elem())
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{t.Params(), t.Results()} {
+			for i := 0; i < tuple.Len(); i++ {
+				if name, ok := unexportedType(pkg, tuple.At(i).Type()); ok {
+					return name, true
+				}
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if name, ok := unexportedType(pkg, t.Field(i).Type()); ok {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// createShimName returns the name of the exported method which sets an
+// unexported field for setters generated in another package
+func createShimName(capitalized string) string {
+	return "Set" + capitalized
+}
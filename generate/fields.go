@@ -0,0 +1,161 @@
+package generate
+
+import (
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+)
+
+// optionsTag is the struct tag which controls the setter generated for a
+// field, such as `options:"-"` or `options:"name=Hostname"`
+const optionsTag = "options"
+
+// sizeOption is the option of the `options` tag which marks an integer field
+// as a size in bytes
+const sizeOption = "size"
+
+// fieldTag is the parsed `options` tag of a field
+type fieldTag struct {
+	skip bool
+	name string
+	size bool
+}
+
+// parseFieldTag parses the `options` tag of a field.  A tag of `-` skips the
+// field, `name=X` generates its setters as if the field were named `X`, and
+// `size` parses the field from strings such as "64MiB".
+func parseFieldTag(tag string) (*fieldTag, error) {
+	value, ok := reflect.StructTag(tag).Lookup(optionsTag)
+	if !ok {
+		return &fieldTag{}, nil
+	}
+	if value == "-" {
+		return &fieldTag{skip: true}, nil
+	}
+
+	ft := &fieldTag{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == sizeOption {
+			ft.size = true
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[0] != "name" {
+			return nil, errors.Errorf("Unknown option '%s'; expected '-', 'name=X' or '%s'", part, sizeOption)
+		}
+		if !token.IsIdentifier(kv[1]) {
+			return nil, errors.Errorf("Setter name '%s' is not a Go identifier", kv[1])
+		}
+		ft.name = kv[1]
+	}
+	return ft, nil
+}
+
+// fieldFilter selects the fields which get setters by name.  A field must
+// match one of the included patterns, if there are any, and none of the
+// excluded patterns.
+type fieldFilter struct {
+	include []glob.Glob
+	exclude []glob.Glob
+}
+
+// newFieldFilter compiles the included and excluded field name patterns
+func newFieldFilter(include, exclude []string) (*fieldFilter, error) {
+	ff := &fieldFilter{}
+	var err error
+	if ff.include, err = compileGlobs(include); err != nil {
+		return nil, err
+	}
+	if ff.exclude, err = compileGlobs(exclude); err != nil {
+		return nil, err
+	}
+	return ff, nil
+}
+
+func compileGlobs(patterns []string) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, len(patterns))
+	for k, pattern := range patterns {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid field pattern '%s'", pattern)
+		}
+		globs[k] = g
+	}
+	return globs, nil
+}
+
+// match reports whether the field with the provided name gets a setter
+func (ff *fieldFilter) match(name string) bool {
+	if ff == nil {
+		return true
+	}
+	if len(ff.include) != 0 && !matchAny(ff.include, name) {
+		return false
+	}
+	return !matchAny(ff.exclude, name)
+}
+
+func matchAny(globs []glob.Glob, name string) bool {
+	for _, g := range globs {
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// setterField is a field of a struct which gets a setter
+type setterField struct {
+	v     *types.Var
+	tag   string
+	index int
+	size  bool
+
+	// name is the capitalized name the setter is generated with
+	name string
+}
+
+// setterFields returns the fields of the struct which get setters, in
+// declaration order.  Embedded options structs are set through their own
+// setters, and `options.Presence` is set by the others.  Fields may be
+// skipped or renamed by their `options` tag, or by the included and excluded
+// patterns.
+func (g *Generator) setterFields(pkg *types.Package, structName string, s *types.Struct) ([]setterField, error) {
+	fields := []setterField{}
+	byName := map[string]string{}
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if n, ok := derefNamed(f.Type()); ok && f.Anonymous() && isOptioner(pkg, n) {
+			continue
+		}
+		if isPresence(f.Type()) {
+			continue
+		}
+
+		ft, err := parseFieldTag(s.Tag(i))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid '%s' tag for field '%s.%s'", optionsTag, structName, f.Name())
+		}
+		if ft.skip || !g.fields.match(f.Name()) {
+			continue
+		}
+
+		name := ft.name
+		if name == "" {
+			name = f.Name()
+		}
+		_, name = abbreviate(name)
+		if other, ok := byName[name]; ok {
+			return nil, errors.Errorf("Fields '%s.%s' and '%s.%s' would both have setters named '%s'", structName, other, structName, f.Name(), name)
+		}
+		byName[name] = f.Name()
+
+		fields = append(fields, setterField{v: f, tag: s.Tag(i), index: i, name: name, size: ft.size})
+	}
+	return fields, nil
+}
@@ -0,0 +1,63 @@
+package generate
+
+import (
+	"go/doc"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FuncMap returns the functions available to templates, which include the
+// naming helpers used by the bundled template
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"abbreviate": func(in string) string {
+			abbr, _ := abbreviate(in)
+			return abbr
+		},
+		"capitalize":      capitalize,
+		"comment":         comment,
+		"constructorName": createConstructorName,
+		"instanceName":    createInstanceName,
+		"join":            strings.Join,
+		"lower":           strings.ToLower,
+		"quote":           strconv.Quote,
+		"synopsis":        doc.Synopsis,
+		"trimSpace":       strings.TrimSpace,
+		"typeVar":         createTypeVar,
+		"uncapitalize":    uncapitalize,
+		"upper":           strings.ToUpper,
+	}
+}
+
+// capitalize returns the string with its first letter in upper case
+func capitalize(in string) string {
+	r, size := utf8.DecodeRuneInString(in)
+	return string(unicode.ToUpper(r)) + in[size:]
+}
+
+// uncapitalize returns the string with its first letter in lower case
+func uncapitalize(in string) string {
+	r, size := utf8.DecodeRuneInString(in)
+	return string(unicode.ToLower(r)) + in[size:]
+}
+
+// comment formats the text, such as a doc comment, as line comments
+func comment(text string) string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return ""
+	}
+
+	lines := strings.Split(text, "\n")
+	for k, line := range lines {
+		if line == "" {
+			lines[k] = "//"
+		} else {
+			lines[k] = "// " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,8 +1,15 @@
 package generate
 
 import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
 	"io"
+	"io/ioutil"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"text/template"
 	"unicode"
@@ -16,6 +23,17 @@ import (
 	// "github.com/spf13/afero"
 )
 
+// templateName is the name of the bundled template asset
+const templateName = "options.template"
+
+// DefaultOutputPattern is the default pattern for the names of generated
+// files, such as `foo_gen.go` for the structs declared in `foo.go`
+const DefaultOutputPattern = outputPatternName + "_gen.go"
+
+// outputPatternName is replaced in output patterns by the name of the source
+// file, without its extension
+const outputPatternName = "{name}"
+
 // Generator will create options helper structs and functions
 type Generator struct {
 	logger log.Logger
@@ -23,12 +41,33 @@ type Generator struct {
 
 	l    *loader.Loader
 	args *[]Arg
+
+	style    Style
+	prefix   string
+	dispatch Dispatch
+	conflict Conflict
+
+	destination   string
+	packageName   string
+	shims         bool
+	outputPattern string
+
+	templatePath string
+	templateSrc  string
+
+	include []string
+	exclude []string
+	fields  *fieldFilter
 }
 
 type Arg struct {
 	Source     string
 	StructName string
 	// Destination string
+
+	// Output is the name of the generated file, if it should not be named
+	// after the struct's source file
+	Output string
 }
 
 // NewGenerator creates a new `Generator` instance and returns a pointer to it
@@ -36,7 +75,12 @@ func NewGenerator(l *loader.Loader, options ...Option) *Generator {
 	g := &Generator{
 		logger: log.Stderr(),
 		// ready:  make(chan struct{}, 1),
-		l: l,
+		l:        l,
+		style:    StructStyle,
+		prefix:   "Set",
+		dispatch: StaticDispatch,
+
+		outputPattern: DefaultOutputPattern,
 	}
 
 	g.logger.SetLevel(log.Debug)
@@ -51,66 +95,558 @@ func NewGenerator(l *loader.Loader, options ...Option) *Generator {
 	return g
 }
 
-// Generate creates the options source
+// File is a generated source file
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Generate creates the options source and writes it to the writer.  Options
+// generated into another package produce more than one file, and must be
+// created with GenerateFiles instead.
 func (g *Generator) Generate(arg Arg, writer io.Writer) error {
-	absFile, err := filepath.Abs(arg.Source)
+	files, err := g.GenerateFiles(arg)
 	if err != nil {
 		return err
 	}
-	p, err := g.l.FindPackage(absFile)
+	if len(files) != 1 {
+		return errors.Errorf("Options for '%s' are generated into %d files; use GenerateFiles", arg.StructName, len(files))
+	}
+
+	_, err = writer.Write(files[0].Content)
+	return err
+}
+
+// GenerateFiles creates the options source for each of the structs, and
+// returns the files which hold it.  Structs declared in the same source file
+// share a single generated file.  When the destination is another package,
+// the setters and constructors are generated there, and the methods of the
+// structs are generated beside them.
+func (g *Generator) GenerateFiles(args ...Arg) ([]*File, error) {
+	if err := checkOutputPattern(g.outputPattern); err != nil {
+		return nil, err
+	}
+
+	fields, err := newFieldFilter(g.include, g.exclude)
 	if err != nil {
-		return errors.Wrapf(err, "Failed to find package at '%s'", absFile)
+		return nil, err
 	}
+	g.fields = fields
 
-	buf, err := assets.Asset("options.template")
+	tmpl, tmplSrc, err := g.loadTemplate()
 	if err != nil {
-		return errors.Wrapf(err, "Failed to get template from assets")
+		return nil, err
+	}
+
+	outputs := []*output{}
+	byPath := map[string]*output{}
+	for _, arg := range args {
+		t, err := g.findTarget(arg)
+		if err != nil {
+			return nil, err
+		}
+		targetOutputs, err := g.createOutputs(t, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, out := range targetOutputs {
+			existing, ok := byPath[out.path]
+			if !ok {
+				byPath[out.path] = out
+				outputs = append(outputs, out)
+				existing = out
+			} else if existing.setters != out.setters || existing.methods != out.methods || existing.importPath != out.importPath {
+				return nil, errors.Errorf("Options for '%s' cannot be generated into '%s', which holds other generated code", arg.StructName, out.path)
+			} else if arg.Output == "" && existing.targets[0].filename != t.filename {
+				// Only the structs of a single source file share an output, unless
+				// it is named explicitly.
+				return nil, errors.Errorf("Output pattern '%s' generates the options of '%s' and '%s' into the same file '%s'; include '%s' in it", g.outputPattern, existing.targets[0].filename, t.filename, out.path, outputPatternName)
+			}
+			if !existing.hasTarget(t) {
+				existing.targets = append(existing.targets, t)
+			}
+		}
+	}
+
+	for _, out := range outputs {
+		out.sortTargets()
+	}
+	g.inspect(outputs)
+
+	files := make([]*File, len(outputs))
+	for k, out := range outputs {
+		data, err := g.createData(out)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, data)
+		if err != nil {
+			return nil, errors.Wrapf(annotateTemplateError(err, tmpl.Name(), tmplSrc), "Failed to execute template")
+		}
+
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(annotateSourceError(err, buf.Bytes()), "Generated code for '%s' is not valid Go", out.path)
+		}
+
+		files[k] = &File{Path: out.path, Content: src}
+	}
+
+	if err := checkOutputs(outputs, files); err != nil {
+		return nil, err
 	}
-	tmpl, err := template.New("t").Parse(string(buf))
+
+	return files, nil
+}
+
+// checkOutputs type-checks the files generated into each package together,
+// so that the declarations of sibling files are checked against each other.
+// Only files in the structs' own package can be type-checked; setters in
+// another package depend on the methods generated beside the structs.
+func checkOutputs(outputs []*output, files []*File) error {
+	pkgs := []*loader.Package{}
+	srcs := map[*loader.Package]map[string][]byte{}
+	for k, out := range outputs {
+		if !out.methods {
+			continue
+		}
+		p := out.targets[0].pkg
+		if _, ok := srcs[p]; !ok {
+			pkgs = append(pkgs, p)
+			srcs[p] = map[string][]byte{}
+		}
+		srcs[p][filepath.Base(out.path)] = files[k].Content
+	}
+
+	for _, p := range pkgs {
+		err := p.CheckFiles(srcs[p])
+		if err == nil {
+			continue
+		}
+		fes, ok := err.(loader.FileErrors)
+		if !ok {
+			return err
+		}
+		for k, out := range outputs {
+			own := loader.FileErrors{}
+			for _, fe := range fes {
+				if fe.Filename == out.path {
+					own = append(own, fe)
+				}
+			}
+			if len(own) != 0 {
+				return errors.Wrapf(annotateSourceError(own, files[k].Content), "Generated code for '%s' does not type-check", out.path)
+			}
+		}
+	}
+	return nil
+}
+
+// loadTemplate parses the template which generates the code; the bundled
+// template, unless another has been provided
+func (g *Generator) loadTemplate() (*template.Template, []byte, error) {
+	name := templateName
+	var tmplSrc []byte
+	var err error
+	switch {
+	case g.templatePath != "":
+		name = filepath.Base(g.templatePath)
+		tmplSrc, err = ioutil.ReadFile(g.templatePath)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Failed to read template '%s'", g.templatePath)
+		}
+	case g.templateSrc != "":
+		name = "custom.template"
+		tmplSrc = []byte(g.templateSrc)
+	default:
+		tmplSrc, err = assets.Asset(templateName)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Failed to get template from assets")
+		}
+	}
+
+	tmpl, err := template.New(name).Funcs(FuncMap()).Parse(string(tmplSrc))
 	if err != nil {
-		return errors.Wrapf(err, "Failed to load template")
+		return nil, nil, errors.Wrapf(annotateTemplateError(err, name, tmplSrc), "Failed to load template '%s'", name)
 	}
+	return tmpl, tmplSrc, nil
+}
 
-	// fs := afero.NewMemMapFs()
-	// afs := &afero.Afero{Fs: fs}
-	// f, err = afs.TempFile("", "ioutil-test")
-	// if err != nil {
-	// 	return errors.Wrapf(err, "Failed to create temp file")
-	// }
+// findTarget locates the struct named by the argument
+func (g *Generator) findTarget(arg Arg) (*target, error) {
+	absFile, err := filepath.Abs(arg.Source)
+	if err != nil {
+		return nil, err
+	}
+	p, err := g.l.FindPackage(absFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to find package at '%s'", absFile)
+	}
 
-	_, s, err := p.FindSource(arg.StructName)
+	filename, s, err := p.FindSource(arg.StructName)
 	if err != nil {
-		return errors.Wrapf(err, "Package '%s' does not contain struct '%s'", p.Name(), arg.StructName)
+		return nil, errors.Wrapf(err, "Package '%s' does not contain struct '%s'", p.Name(), arg.StructName)
 	}
 	g.logger.Infof("Have struct:\n%#v\n", s)
 
+	named, err := findNamed(p.Types(), arg.StructName)
+	if err != nil {
+		return nil, err
+	}
+
+	presence, err := findPresence(arg.StructName, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &target{pkg: p, name: arg.StructName, filename: filename, named: named, s: s, presence: presence}, nil
+}
+
+// createOutputs returns the files which hold the generated code for the
+// struct
+func (g *Generator) createOutputs(t *target, arg Arg) ([]*output, error) {
+	outputFilename := arg.Output
+	if outputFilename == "" {
+		outputFilename = OutputFilename(g.outputPattern, t.filename)
+	}
+
+	source := &output{
+		path:       filepath.Join(t.pkg.AbsPath, outputFilename),
+		importPath: t.pkg.ImportPath,
+		pkgName:    t.pkg.Name(),
+		setters:    true,
+		methods:    true,
+	}
+	if source.path == t.filename {
+		return nil, errors.Errorf("Options for '%s' would overwrite its source file '%s'", t.name, t.filename)
+	}
+
+	dest, err := g.destinationDir(t.pkg)
+	if err != nil {
+		return nil, err
+	}
+	if dest == t.pkg.AbsPath {
+		return []*output{source}, nil
+	}
+
+	external := &output{
+		path:       filepath.Join(dest, outputFilename),
+		importPath: g.l.FindDirectoryImportPath(dest),
+		pkgName:    g.destinationPackage(dest),
+		setters:    true,
+		split:      true,
+	}
+	if err := g.checkExternal(t.pkg.Types(), t.name, t.s, external); err != nil {
+		return nil, err
+	}
+	if t.presence != nil && !t.presence.Exported() {
+		return nil, errors.Errorf("Field '%s.%s' is unexported, so the presence of fields cannot be recorded from package '%s'; export it", t.name, t.presence.Name(), external.pkgName)
+	}
+	source.setters = false
+	source.split = true
+	return []*output{source, external}, nil
+}
+
+// createData collects the template data for the generated code which is
+// written to the output
+func (g *Generator) createData(out *output) (*templates.Data, error) {
+	sources, inputHash, err := g.createProvenance(out)
+	if err != nil {
+		return nil, err
+	}
+
 	data := &templates.Data{
-		Package:       p.Name(),
-		InstanceName:  createInstanceName(arg.StructName),
-		StructName:    arg.StructName,
-		StructMembers: make([]templates.FuncData, s.NumFields()),
+		Version:        Version,
+		Sources:        sources,
+		InputHash:      inputHash,
+		Package:        out.pkgName,
+		MethodSetters:  g.style == MethodStyle,
+		StaticDispatch: g.dispatch == StaticDispatch,
+		Setters:        out.setters,
+		Methods:        out.methods,
+		Structs:        []templates.StructData{},
+	}
+
+	used := []string{optionsImportPath}
+	if out.methods {
+		used = []string{"reflect", optionsImportPath}
+	}
+	imps := newImports(out.importPath, used...)
+	tvs := newTypeVars(out.declared)
+
+	// Each struct's own type variable is declared first, so that embedding
+	// structs in the same file share it.  Only the methods use them.
+	if out.methods {
+		for _, t := range out.targets {
+			tvs.add(createTypeVar(t.name), t.name)
+		}
+	}
+
+	for _, t := range out.targets {
+		sd, err := g.createStructData(t, out, imps, tvs)
+		if err != nil {
+			return nil, err
+		}
+		data.Structs = append(data.Structs, *sd)
+	}
+
+	data.TypeVars = tvs.vars
+	data.ImportGroups = imps.groups()
+
+	return data, nil
+}
+
+// createStructData collects the template data for a single struct
+func (g *Generator) createStructData(t *target, out *output, imps *imports, tvs *typeVars) (*templates.StructData, error) {
+	pkg := t.pkg.Types()
+	docs, ok := t.pkg.FindDocs(t.name, Directive)
+	if !ok {
+		docs = &loader.TypeDocs{Fields: map[string]loader.FieldDocs{}}
+	}
+
+	sd := &templates.StructData{
+		Doc:             docs.Doc,
+		StructName:      t.name,
+		StructRef:       t.name,
+		OptName:         t.optName,
+		OptRef:          t.optName,
+		ConstructorName: createConstructorName(t.name),
+		HasValidate:     hasValidate(t.named),
+		StructMembers:   []templates.FuncData{},
+	}
+
+	if out.methods {
+		if err := checkMethods(t); err != nil {
+			return nil, err
+		}
+		sd.TypeVar = tvs.add(createTypeVar(t.name), t.name)
+		for _, e := range collectEmbedded(pkg, t.s) {
+			sd.Embedded = append(sd.Embedded, e.templateData(pkg, imps, tvs, t.name, g.dispatch == StaticDispatch))
+		}
+	}
+	if out.split {
+		sd.StructRef = imps.typeString(t.named)
+		if name := imps.qualifier(t.named.Obj().Pkg()); name != "" {
+			sd.OptRef = name + "." + t.optName
+		}
 	}
 
-	for i := 0; i < s.NumFields(); i++ {
-		f := s.Field(i)
+	defaults, err := structDefaults(pkg, t.name, t.s)
+	if err != nil {
+		return nil, err
+	}
+	sd.Defaults = defaults
+
+	fields, err := g.setterFields(pkg, t.name, t.s)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPresence(t, fields); err != nil {
+		return nil, err
+	}
+	if t.presence != nil {
+		sd.Presence = t.presence.Name()
+	}
+	for _, sf := range fields {
+		f := sf.v
 		name := f.Name()
-		abbreviatedName, capitalizedName := abbreviate(name)
-		data.StructMembers[i] = templates.FuncData{
+		defaultValue, hasDefault, err := fieldDefault(f, sf.tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to process struct '%s'", t.name)
+		}
+		// The output beside the struct of split code only writes the types of
+		// the fields which it has shims for, so only those are imported.
+		typeString, typeData := imps.typeString, imps.typeData
+		if !out.setters && f.Exported() {
+			typeString, typeData = imps.peekTypeString, imps.peekTypeData
+		}
+		fd := templates.FuncData{
+			Doc:             docs.Fields[name].Doc,
+			Comment:         docs.Fields[name].Comment,
+			Tag:             reflect.StructTag(sf.tag),
+			Type:            typeData(f.Type()),
 			OptionName:      name,
-			OptionNameLower: abbreviatedName,
-			OptionNameUpper: capitalizedName,
-			OptionType:      f.Type().String(),
+			OptionNameUpper: sf.name,
+			OptionType:      typeString(f.Type()),
+			SetterName:      g.style.setterName(t.name, g.prefix, sf.name),
+			Default:         defaultValue,
+			HasDefault:      hasDefault,
 		}
+		g.collectionSetters(&fd, t.name, sf.name, f, out.split, typeString)
+		if err := g.convenienceSetters(&fd, t.name, sf); err != nil {
+			return nil, err
+		}
+		sd.StructMembers = append(sd.StructMembers, fd)
 	}
 
-	err = tmpl.Execute(writer, data)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to execute template")
+	// Names are chosen once every import of the struct's code is known, so
+	// that none of them is shadowed.
+	sd.InstanceName = receiverNamer(t, imps, tvs).name(createInstanceName(t.name))
+	params := newNamer(sd.InstanceName, sd.InstanceName+"o", t.name, t.optName)
+	for name := range imps.byName {
+		params.reserve(name)
+	}
+	members := memberNamer(t)
+	if out.setters {
+		if sd.ConstructorName, err = g.resolveConflict(out.declared, t.name, sd.ConstructorName); err != nil {
+			return nil, err
+		}
+		declared := g.declaredNamer(t, out)
+		for k := range sd.StructMembers {
+			fd := &sd.StructMembers[k]
+			if fd.SetterName, err = g.resolveConflict(declared, t.name, fd.SetterName); err != nil {
+				return nil, err
+			}
+		}
+		// Setters which replace a field take precedence over those which
+		// modify it.
+		for k := range sd.StructMembers {
+			fd := &sd.StructMembers[k]
+			for _, name := range []*string{&fd.AppendName, &fd.PutName, &fd.DeleteName, &fd.EnableName, &fd.DisableName, &fd.FromStringName} {
+				if *name == "" {
+					continue
+				}
+				if *name, err = g.resolveConflict(declared, t.name, *name); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for k, sf := range fields {
+		fd := &sd.StructMembers[k]
+		fd.InstanceName = sd.InstanceName
+		abbreviatedName, _ := abbreviate(sf.v.Name())
+		fd.OptionNameLower = params.name(abbreviatedName, uncapitalize(sf.v.Name()))
+		switch {
+		case fd.AppendName != "":
+			fd.ElemParam = params.name("vals")
+		case fd.PutName != "" || fd.DeleteName != "":
+			fd.KeyParam = params.name("k")
+			fd.ElemParam = params.name("v")
+		}
+		if format, exact, ok := stringParser(sf.v.Type(), sf.size); ok {
+			fd.StringParam = params.name("s")
+			fd.ParsedName = params.name("v")
+			fd.Parse = fmt.Sprintf(format, fd.StringParam)
+			fd.Parsed = fd.ParsedName
+			if !exact {
+				fd.Parsed = fmt.Sprintf("%s(%s)", fd.OptionType, fd.ParsedName)
+			}
+		}
+		if out.split && !sf.v.Exported() {
+			fd.Shim = members.name(createShimName(sf.name))
+			members.reserve(fd.Shim)
+		}
+		if t.presence != nil {
+			fd.Index = k
+			fd.IsSetName = members.name("IsSet" + sf.name)
+			members.reserve(fd.IsSetName)
+		}
 	}
 
+	return sd, nil
+}
+
+// OutputFilename returns the name of the file generated for structs declared
+// in the provided source file, according to the pattern.  Each `{name}` in
+// the pattern is replaced by the source file's name, without its extension.
+func OutputFilename(pattern, source string) string {
+	filename := filepath.Base(source)
+	filename = strings.TrimSuffix(filename, filepath.Ext(filename))
+	return strings.Replace(pattern, outputPatternName, filename, -1)
+}
+
+// checkOutputPattern reports whether the output filename pattern names a Go
+// source file in the same directory as the struct
+func checkOutputPattern(pattern string) error {
+	if !strings.HasSuffix(pattern, ".go") || strings.HasSuffix(pattern, "_test.go") {
+		return errors.Errorf("Output pattern '%s' must name a non-test Go file", pattern)
+	}
+	if strings.ContainsAny(pattern, `/\`) {
+		return errors.Errorf("Output pattern '%s' must not contain a directory", pattern)
+	}
 	return nil
 }
 
+// isOptioner reports whether an embedded field of type `n` is an options
+// struct.  Named structs from other packages must already have an `Apply`
+// method; named structs from the same package are expected to be generated
+// alongside the embedding struct.
+func isOptioner(pkg *types.Package, n *types.Named) bool {
+	if _, ok := n.Underlying().(*types.Struct); !ok {
+		return false
+	}
+	if n.Obj().Pkg() == pkg {
+		return true
+	}
+	return hasMethod(n, "Apply")
+}
+
+// hasMethod reports whether `*n` has an exported method with the provided
+// name
+func hasMethod(n *types.Named, name string) bool {
+	ms := types.NewMethodSet(types.NewPointer(n))
+	return ms.Lookup(nil, name) != nil
+}
+
+// hasValidate reports whether `n` declares a user-written `validate() error`
+// method.  A `validate` promoted from an embedded struct is not its own; it is
+// run by the embedded struct's `Validate`.
+func hasValidate(n *types.Named) bool {
+	for i := 0; i < n.NumMethods(); i++ {
+		m := n.Method(i)
+		if m.Name() != "validate" {
+			continue
+		}
+		sig := m.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			return false
+		}
+		return types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+	}
+	return false
+}
+
+// findNamed returns the named type declared in the package with the
+// provided name
+func findNamed(pkg *types.Package, name string) (*types.Named, error) {
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, errors.Errorf("Package '%s' does not declare '%s'", pkg.Name(), name)
+	}
+	n, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, errors.Errorf("Declaration '%s' is not a named type", name)
+	}
+	return n, nil
+}
+
+// createConstructorName returns the name of the generated constructor, which
+// is exported only if the struct is exported
+func createConstructorName(in string) string {
+	if ast.IsExported(in) {
+		return "New" + in
+	}
+	r, size := utf8.DecodeRuneInString(in)
+	return "new" + string(unicode.ToUpper(r)) + in[size:]
+}
+
+// createOptName returns the name of the type of the options which set the
+// fields of the provided struct
+func createOptName(in string) string {
+	return in + "Opt"
+}
+
+// createTypeVar returns the name of the package-level variable which holds
+// the `reflect.Type` for the provided name
+func createTypeVar(in string) string {
+	r, size := utf8.DecodeRuneInString(in)
+	return string(unicode.ToLower(r)) + in[size:] + "Type"
+}
+
 func createInstanceName(in string) string {
 	var name strings.Builder
 
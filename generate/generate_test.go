@@ -2,95 +2,1298 @@ package generate
 
 import (
 	"bytes"
+	"errors"
+	"flag"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/object88/options"
+	"github.com/object88/options/generate/testdata/defaults"
+	"github.com/object88/options/generate/testdata/dispatch/other"
+	"github.com/object88/options/generate/testdata/dispatch/reflective"
+	"github.com/object88/options/generate/testdata/dispatch/static"
+	"github.com/object88/options/generate/testdata/external/setters"
+	"github.com/object88/options/generate/testdata/fields"
+	importsfixture "github.com/object88/options/generate/testdata/imports"
+	importsoptions "github.com/object88/options/generate/testdata/imports/options"
+	"github.com/object88/options/generate/testdata/convenience"
+	"github.com/object88/options/generate/testdata/merged"
+	"github.com/object88/options/generate/testdata/presence"
+	"github.com/object88/options/generate/testdata/validate"
 	"github.com/object88/options/loader"
 	logtest "github.com/object88/options/log/testing"
 )
 
+var update = flag.Bool("update", false, "update the generated fixtures in testdata")
+
 type gentest struct {
-	name    string
-	sources map[string]string
-	funcs   map[string]string
+	name     string
+	sources  map[string]string
+	options  []Option
+	funcs    map[string]string
+	receiver bool
+	err      bool
+}
+
+func Test_Generate(t *testing.T) {
+	tcs := []struct {
+		name string
+		gt   gentest
+	}{
+		{
+			name: "One field",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+				},
+				funcs: map[string]string{
+					"FooOptionsSetA": "string",
+				},
+			},
+		},
+		{
+			name: "Two fields",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n  b int\n}\n",
+				},
+				funcs: map[string]string{
+					"FooOptionsSetA": "string",
+					"FooOptionsSetB": "int",
+				},
+			},
+		},
+		{
+			name: "Field name variations",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  A string\n  b int\n  cWithCamelCase bool}\n",
+				},
+				funcs: map[string]string{
+					"FooOptionsSetA":              "string",
+					"FooOptionsSetB":              "int",
+					"FooOptionsSetCWithCamelCase": "bool",
+				},
+			},
+		},
+		{
+			name: "Field abbreviations collide",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  fOther string\n  len int\n  fooOptionsType bool\n}\n",
+				},
+				funcs: map[string]string{
+					"FooOptionsSetFOther":         "string",
+					"FooOptionsSetLen":            "int",
+					"FooOptionsSetFooOptionsType": "bool",
+				},
+			},
+		},
+		{
+			name: "Field abbreviation collides with receiver",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  fOther string\n}\n",
+				},
+				options: []Option{SetStyle(MethodStyle)},
+				funcs: map[string]string{
+					"SetFOther": "string",
+				},
+				receiver: true,
+			},
+		},
+		{
+			name: "Prefix style",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n  b int\n}\n",
+				},
+				options: []Option{SetStyle(PrefixStyle), SetPrefix("With")},
+				funcs: map[string]string{
+					"WithA": "string",
+					"WithB": "int",
+				},
+			},
+		},
+		{
+			name: "Method style",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n  b int\n}\n",
+				},
+				options: []Option{SetStyle(MethodStyle)},
+				funcs: map[string]string{
+					"SetA": "string",
+					"SetB": "int",
+				},
+				receiver: true,
+			},
+		},
+		{
+			name: "Method style collections",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a []string\n  b map[string]int\n}\n",
+				},
+				options: []Option{SetStyle(MethodStyle)},
+				funcs: map[string]string{
+					"SetA":    "[]string",
+					"AppendA": "string",
+					"SetB":    "map[string]int",
+					"DeleteB": "string",
+				},
+				receiver: true,
+			},
+		},
+		{
+			name: "Collection setter collides with prefix",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a []string\n}\n",
+				},
+				options: []Option{SetStyle(PrefixStyle), SetPrefix("Append")},
+				err:     true,
+			},
+		},
+		{
+			name: "Existing setter",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n\nfunc FooOptionsSetA(a string) {}\n",
+				},
+				err: true,
+			},
+		},
+		{
+			name: "Existing setter skipped",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n  b int\n}\n\nfunc FooOptionsSetA(a string) {}\n",
+				},
+				options: []Option{SetConflict(SkipConflict)},
+				funcs: map[string]string{
+					"FooOptionsSetB": "int",
+				},
+			},
+		},
+		{
+			name: "Existing setter renamed",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n\nfunc FooOptionsSetA(a string) {}\n",
+				},
+				options: []Option{SetConflict(RenameConflict)},
+				funcs: map[string]string{
+					"FooOptionsSetA2": "string",
+				},
+			},
+		},
+		{
+			name: "Existing method setter skipped",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n  b int\n}\n\nfunc (fo *FooOptions) SetA(a string) {}\n",
+				},
+				options: []Option{SetStyle(MethodStyle), SetConflict(SkipConflict)},
+				funcs: map[string]string{
+					"SetB": "int",
+				},
+				receiver: true,
+			},
+		},
+		{
+			name: "Existing constructor skipped",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n\nfunc NewFooOptions() *FooOptions { return nil }\n",
+				},
+				options: []Option{SetConflict(SkipConflict)},
+				funcs: map[string]string{
+					"FooOptionsSetA": "string",
+				},
+			},
+		},
+		{
+			name: "Existing Apply",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n\nfunc (fo *FooOptions) Apply() {}\n",
+				},
+				options: []Option{SetConflict(SkipConflict)},
+				err:     true,
+			},
+		},
+		{
+			name: "Previous output",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go":     "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+					"fooOptions_gen.go": "package foo\n\nfunc FooOptionsSetA(a string) {}\n\nfunc (fo *FooOptions) Apply() {}\n",
+				},
+				funcs: map[string]string{
+					"FooOptionsSetA": "string",
+				},
+			},
+		},
+		{
+			name: "Unexported field in another package",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  A string\n  b int\n}\n",
+				},
+				options: []Option{SetDestination(os.TempDir())},
+				err:     true,
+			},
+		},
+		{
+			name: "Method style in another package",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  A string\n}\n",
+				},
+				options: []Option{SetDestination(os.TempDir()), SetStyle(MethodStyle)},
+				err:     true,
+			},
+		},
+		{
+			name: "Output overwrites source",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+				},
+				options: []Option{SetOutputPattern("{name}.go")},
+				err:     true,
+			},
+		},
+		{
+			name: "Invalid output pattern",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+				},
+				options: []Option{SetOutputPattern("gen/{name}.go")},
+				err:     true,
+			},
+		},
+		{
+			name: "Unknown options tag",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string `options:\"skip\"`\n}\n",
+				},
+				err: true,
+			},
+		},
+		{
+			name: "Size option on a string",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string `options:\"size\"`\n}\n",
+				},
+				err: true,
+			},
+		},
+		{
+			name: "Renamed setter collides",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string `options:\"name=B\"`\n  b string\n}\n",
+				},
+				err: true,
+			},
+		},
+		{
+			name: "Invalid field pattern",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+				},
+				options: []Option{SetExclude("[a")},
+				err:     true,
+			},
+		},
+		{
+			name: "Invalid default",
+			gt: gentest{
+				sources: map[string]string{
+					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a int `default:\"many\"`\n}\n",
+				},
+				err: true,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			basepath := writeSource(t, tc.gt.sources)
+
+			l := loadSource(t, basepath)
+
+			g := NewGenerator(l, append([]Option{SetLog(l.Log)}, tc.gt.options...)...)
+
+			parsedArg := Arg{
+				Source:     basepath,
+				StructName: "FooOptions",
+			}
+
+			var buf bytes.Buffer
+			err := g.Generate(parsedArg, &buf)
+			if tc.gt.err {
+				if err == nil {
+					t.Errorf("Expected error from Generate")
+				} else {
+					t.Logf("Expected error from Generate: %s", err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error from Generate: %s", err.Error())
+			}
+
+			t.Logf("Generated file:\n%s\n", buf.String())
+
+			astf := loadGeneratedCode(t, buf.Bytes())
+			evalulateGeneratedCode(t, astf, tc.gt.funcs, tc.gt.receiver)
+		})
+	}
+}
+
+func Test_Generate_Siblings(t *testing.T) {
+	separate := map[string]string{
+		"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  host string\n}\n",
+		"barOptions.go": "package foo\n\ntype BarOptions struct {\n  host string\n}\n",
+	}
+	together := map[string]string{
+		"options.go": "package foo\n\ntype FooOptions struct {\n  host string\n}\n\ntype BarOptions struct {\n  host string\n}\n",
+	}
+
+	tcs := []struct {
+		name     string
+		sources  map[string]string
+		options  []Option
+		expected []string
+		err      bool
+	}{
+		{
+			name:    "Separate files",
+			sources: separate,
+			err:     true,
+		},
+		{
+			name:     "Separate files renamed",
+			sources:  separate,
+			options:  []Option{SetConflict(RenameConflict)},
+			expected: []string{"SetHost", "SetHost2"},
+		},
+		{
+			name:    "Separate files into one output",
+			sources: separate,
+			options: []Option{SetConflict(RenameConflict), SetOutputPattern("options_gen.go")},
+			err:     true,
+		},
+		{
+			name:    "Same file",
+			sources: together,
+			err:     true,
+		},
+		{
+			name:     "Same file renamed",
+			sources:  together,
+			options:  []Option{SetConflict(RenameConflict)},
+			expected: []string{"SetHost", "SetHost2"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			basepath := writeSource(t, tc.sources)
+			l := loadSource(t, basepath)
+			g := NewGenerator(l, append([]Option{SetLog(l.Log), SetStyle(PrefixStyle)}, tc.options...)...)
+
+			files, err := g.GenerateFiles(
+				Arg{Source: basepath, StructName: "FooOptions"},
+				Arg{Source: basepath, StructName: "BarOptions"},
+			)
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error from GenerateFiles")
+				} else {
+					t.Logf("Expected error from GenerateFiles: %s", err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error from GenerateFiles: %s", err.Error())
+			}
+
+			actual := []string{}
+			for _, f := range files {
+				astf := loadGeneratedCode(t, f.Content)
+				for _, decl := range astf.Decls {
+					if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv == nil && strings.HasPrefix(fd.Name.Name, "SetHost") {
+						actual = append(actual, fd.Name.Name)
+					}
+				}
+			}
+			sort.Strings(actual)
+			if strings.Join(actual, ", ") != strings.Join(tc.expected, ", ") {
+				t.Errorf("Expected setters %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_Generate_PackageNames(t *testing.T) {
+	tcs := []struct {
+		name     string
+		sources  map[string]string
+		expected []string
+	}{
+		{
+			name: "Type variable",
+			sources: map[string]string{
+				"fooOptions.go": "package foo\n\nvar fooOptionsType = 3\n\ntype FooOptions struct {\n  a string\n}\n",
+			},
+			expected: []string{"FooOptionsOpt", "fooOptionsType2"},
+		},
+		{
+			name: "Embedded type variable",
+			sources: map[string]string{
+				"fooOptions.go": "package foo\n\nvar fooOptionsBarOptionsType = 3\n\ntype FooOptions struct {\n  BarOptions\n  a string\n}\n",
+				"barOptions.go": "package foo\n\ntype BarOptions struct {\n  b string\n}\n",
+			},
+			expected: []string{"BarOptionsOpt", "FooOptionsOpt", "barOptionsType", "fooOptionsBarOptionsType2", "fooOptionsType"},
+		},
+		{
+			name: "Option type",
+			sources: map[string]string{
+				"fooOptions.go": "package foo\n\ntype FooOptionsOpt int\n\ntype FooOptions struct {\n  a string\n}\n",
+			},
+			expected: []string{"FooOptionsOpt2", "fooOptionsType"},
+		},
+		{
+			name: "Previously generated",
+			sources: map[string]string{
+				"fooOptions.go":     "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+				"fooOptions_gen.go": "package foo\n\nvar fooOptionsType = 3\n\ntype FooOptionsOpt int\n",
+			},
+			expected: []string{"FooOptionsOpt", "fooOptionsType"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			basepath := writeSource(t, tc.sources)
+			l := loadSource(t, basepath)
+			g := NewGenerator(l, SetLog(l.Log))
+
+			args := []Arg{{Source: basepath, StructName: "FooOptions"}}
+			if _, ok := tc.sources["barOptions.go"]; ok {
+				args = append(args, Arg{Source: basepath, StructName: "BarOptions"})
+			}
+			files, err := g.GenerateFiles(args...)
+			if err != nil {
+				t.Fatalf("Unexpected error from GenerateFiles: %s", err.Error())
+			}
+
+			actual := []string{}
+			for _, f := range files {
+				astf := loadGeneratedCode(t, f.Content)
+				for _, decl := range astf.Decls {
+					gd, ok := decl.(*ast.GenDecl)
+					if !ok {
+						continue
+					}
+					for _, spec := range gd.Specs {
+						switch spec := spec.(type) {
+						case *ast.TypeSpec:
+							actual = append(actual, spec.Name.Name)
+						case *ast.ValueSpec:
+							for _, name := range spec.Names {
+								actual = append(actual, name.Name)
+							}
+						}
+					}
+				}
+			}
+			sort.Strings(actual)
+			if strings.Join(actual, ", ") != strings.Join(tc.expected, ", ") {
+				t.Errorf("Expected package-level declarations %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_Generate_Order(t *testing.T) {
+	basepath := writeSource(t, map[string]string{
+		"options.go": "package foo\n\n// generate-options\ntype FooOptions struct {\n  host string\n}\n\n// generate-options\ntype BarOptions struct {\n  port int\n}\n",
+	})
+	l := loadSource(t, basepath)
+	g := NewGenerator(l, SetLog(l.Log))
+
+	tcs := []struct {
+		name string
+		args []Arg
+	}{
+		{
+			name: "Declared order",
+			args: []Arg{{Source: basepath, StructName: "FooOptions"}, {Source: basepath, StructName: "BarOptions"}},
+		},
+		{
+			name: "Reversed order",
+			args: []Arg{{Source: basepath, StructName: "BarOptions"}, {Source: basepath, StructName: "FooOptions"}},
+		},
+		{
+			name: "Discovered",
+			args: Discover(l, basepath, false),
+		},
+	}
+
+	contents := map[string]bool{}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := g.GenerateFiles(tc.args...)
+			if err != nil {
+				t.Fatalf("Unexpected error from GenerateFiles: %s", err.Error())
+			}
+			if len(files) != 1 {
+				t.Fatalf("Expected 1 file, got %d", len(files))
+			}
+			if !bytes.Contains(files[0].Content, []byte("// Structs: FooOptions, BarOptions\n")) {
+				t.Errorf("Expected structs in the order of their declarations:\n%s", string(files[0].Content))
+			}
+			contents[string(files[0].Content)] = true
+		})
+	}
+
+	if len(contents) != 1 {
+		t.Errorf("Expected the same generated code for every order of arguments, got %d variants", len(contents))
+	}
+}
+
+func Test_Generate_Template(t *testing.T) {
+	tmpl := `package {{ .Package }}
+{{ range .Structs }}
+{{ comment .Doc }}
+var {{ uncapitalize .StructName }}Fields = []string{
+{{- range .StructMembers }}
+	{{ quote (printf "%s %s %s %s %s" .OptionName .Type.Kind (.Tag.Get "json") (trimSpace .Doc) (trimSpace .Comment)) }},
+{{- end }}
+}
+{{ end }}`
+
+	basepath := writeSource(t, map[string]string{
+		"fooOptions.go": "package foo\n\n// FooOptions configures foo\n//\n// generate-options\n//go:generate options FooOptions\ntype FooOptions struct {\n  // Host is the host\n  Host string `json:\"host\"`\n  ports []int // the ports\n}\n",
+	})
+	l := loadSource(t, basepath)
+	g := NewGenerator(l, SetLog(l.Log), SetTemplate(tmpl))
+
+	var buf bytes.Buffer
+	if err := g.Generate(Arg{Source: basepath, StructName: "FooOptions"}, &buf); err != nil {
+		t.Fatalf("Unexpected error from Generate: %s", err.Error())
+	}
+
+	expected := "package foo\n\n// FooOptions configures foo\nvar fooOptionsFields = []string{\n\t\"Host basic host Host is the host \",\n\t\"ports slice   the ports\",\n}\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func Test_Generate_Template_Errors(t *testing.T) {
+	tcs := []struct {
+		name     string
+		tmpl     string
+		position string
+		line     string
+	}{
+		{
+			name:     "Template does not parse",
+			tmpl:     "package {{ .Package }}\n\n{{ range .Structs }}\n// {{ .StructName }\n{{ end }}\n",
+			position: "custom.template:4",
+			line:     "// {{ .StructName }",
+		},
+		{
+			name:     "Template does not execute",
+			tmpl:     "package {{ .Package }}\n\n{{ range .Structs }}\n// {{ .Missing }}\n{{ end }}\n",
+			position: "custom.template:4",
+			line:     "// {{ .Missing }}",
+		},
+		{
+			name:     "Generated code is not valid Go",
+			tmpl:     "package {{ .Package }}\n\n{{ range .Structs }}\nfunc {{ .StructName }}Broken( {\n}\n{{ end }}\n",
+			position: ": 4:24: ",
+			line:     "func FooOptionsBroken( {",
+		},
+		{
+			name:     "Generated code does not type-check",
+			tmpl:     "package {{ .Package }}\n\n{{ range .Structs }}\nvar {{ uncapitalize .StructName }}Count int = \"many\"\n{{ end }}\n",
+			position: ": 3:27: ",
+			line:     "var fooOptionsCount int = \"many\"",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			basepath := writeSource(t, map[string]string{
+				"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+			})
+			l := loadSource(t, basepath)
+			g := NewGenerator(l, SetLog(l.Log), SetTemplate(tc.tmpl))
+
+			var buf bytes.Buffer
+			err := g.Generate(Arg{Source: basepath, StructName: "FooOptions"}, &buf)
+			if err == nil {
+				t.Fatalf("Expected error from Generate")
+			}
+			t.Logf("Expected error from Generate: %s", err.Error())
+			if !strings.Contains(err.Error(), tc.position) {
+				t.Errorf("Expected error to name '%s'", tc.position)
+			}
+			if !strings.Contains(err.Error(), "\n\t"+tc.line) {
+				t.Errorf("Expected error to include the line '%s'", tc.line)
+			}
+		})
+	}
+}
+
+func Test_Generate_Header(t *testing.T) {
+	generated := regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+	hash := regexp.MustCompile(`(?m)^// Input hash: (sha256:[0-9a-f]{64})$`)
+
+	hashes := map[string]bool{}
+	for _, source := range []string{
+		"package foo\n\ntype FooOptions struct {\n  a string\n}\n",
+		"package foo\n\ntype FooOptions struct {\n  a int\n}\n",
+	} {
+		basepath := writeSource(t, map[string]string{"fooOptions.go": source})
+		l := loadSource(t, basepath)
+		g := NewGenerator(l, SetLog(l.Log))
+
+		var buf bytes.Buffer
+		if err := g.Generate(Arg{Source: basepath, StructName: "FooOptions"}, &buf); err != nil {
+			t.Fatalf("Unexpected error from Generate: %s", err.Error())
+		}
+
+		if !generated.Match(buf.Bytes()) {
+			t.Errorf("Generated file does not have the standard generated code header:\n%s", buf.String())
+		}
+		if astf := loadGeneratedCode(t, buf.Bytes()); astf.Doc != nil {
+			t.Errorf("Header is attached to the package clause as its doc comment")
+		}
+
+		match := hash.FindSubmatch(buf.Bytes())
+		if match == nil {
+			t.Fatalf("Generated file does not record the input hash:\n%s", buf.String())
+		}
+		hashes[string(match[1])] = true
+	}
+
+	if len(hashes) != 2 {
+		t.Errorf("Expected different sources to have different input hashes")
+	}
 }
 
-func Test_Generate(t *testing.T) {
+func Test_Generate_Header_Embedded(t *testing.T) {
+	hash := regexp.MustCompile(`(?m)^// Input hash: (sha256:[0-9a-f]{64})$`)
+
+	hashes := map[string]bool{}
+	for _, embedded := range []string{
+		"package foo\n\ntype BarOptions struct {\n  b string\n}\n",
+		"package foo\n\ntype BarOptions struct {\n  b int\n}\n",
+	} {
+		basepath := writeSource(t, map[string]string{
+			"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  BarOptions\n  a string\n}\n",
+			"barOptions.go": embedded,
+		})
+		l := loadSource(t, basepath)
+		g := NewGenerator(l, SetLog(l.Log))
+
+		files, err := g.GenerateFiles(
+			Arg{Source: basepath, StructName: "FooOptions"},
+			Arg{Source: basepath, StructName: "BarOptions"},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error from GenerateFiles: %s", err.Error())
+		}
+
+		// Only the file generated for the embedding struct is of interest.
+		match := hash.FindSubmatch(files[0].Content)
+		if match == nil {
+			t.Fatalf("Generated file does not record the input hash:\n%s", string(files[0].Content))
+		}
+		hashes[string(match[1])] = true
+	}
+
+	if len(hashes) != 2 {
+		t.Errorf("Expected a change to an embedded struct to change the input hash")
+	}
+}
+
+func Test_Generate_Header_Unrelated(t *testing.T) {
+	hash := regexp.MustCompile(`(?m)^// Input hash: (sha256:[0-9a-f]{64})$`)
+
+	hashes := map[string]bool{}
+	for _, unrelated := range []string{
+		"",
+		"// Kind is unrelated\ntype Kind int\n\nfunc (b *BarOptions) String() string {\n  return b.b\n}\n",
+	} {
+		basepath := writeSource(t, map[string]string{
+			"fooOptions.go": "package foo\n\n// FooOptions configures foo\ntype FooOptions struct {\n  BarOptions\n  a string\n}\n\n" + unrelated,
+			"barOptions.go": "package foo\n\ntype BarOptions struct {\n  b string\n}\n\n" + strings.Replace(unrelated, "Kind", "Level", -1),
+		})
+		l := loadSource(t, basepath)
+		g := NewGenerator(l, SetLog(l.Log))
+
+		files, err := g.GenerateFiles(
+			Arg{Source: basepath, StructName: "FooOptions"},
+			Arg{Source: basepath, StructName: "BarOptions"},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error from GenerateFiles: %s", err.Error())
+		}
+
+		match := hash.FindSubmatch(files[0].Content)
+		if match == nil {
+			t.Fatalf("Generated file does not record the input hash:\n%s", string(files[0].Content))
+		}
+		hashes[string(match[1])] = true
+	}
+
+	if len(hashes) != 1 {
+		t.Errorf("Expected unrelated declarations in the source files not to change the input hash")
+	}
+}
+
+func Test_Generate_Fixtures(t *testing.T) {
 	tcs := []struct {
-		name string
-		gt   gentest
+		dir     string
+		structs []string
+		options []Option
 	}{
 		{
-			name: "One field",
-			gt: gentest{
-				sources: map[string]string{
-					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n}\n",
-				},
-				funcs: map[string]string{
-					"SetA": "string",
-				},
-			},
+			dir:     "testdata/dispatch/other",
+			structs: []string{"Options"},
 		},
 		{
-			name: "Two fields",
-			gt: gentest{
-				sources: map[string]string{
-					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  a string\n  b int\n}\n",
-				},
-				funcs: map[string]string{
-					"SetA": "string",
-					"SetB": "int",
-				},
-			},
+			dir:     "testdata/dispatch/static",
+			structs: []string{"FooOptions", "BarOptions", "BazOptions", "QuxOptions", "Options"},
+			options: []Option{SetDispatch(StaticDispatch)},
 		},
 		{
-			name: "Field name variations",
-			gt: gentest{
-				sources: map[string]string{
-					"fooOptions.go": "package foo\n\ntype FooOptions struct {\n  A string\n  b int\n  cWithCamelCase bool}\n",
-				},
-				funcs: map[string]string{
-					"SetA":              "string",
-					"SetB":              "int",
-					"SetCWithCamelCase": "bool",
-				},
-			},
+			dir:     "testdata/dispatch/reflective",
+			structs: []string{"FooOptions", "BarOptions", "BazOptions", "QuxOptions", "Options", "HiddenOptions", "hiddenOptions"},
+			options: []Option{SetDispatch(ReflectDispatch)},
+		},
+		{
+			dir:     "testdata/collections",
+			structs: []string{"CollectionsOptions"},
+		},
+		{
+			dir:     "testdata/convenience",
+			structs: []string{"ConvenienceOptions"},
+		},
+		{
+			dir:     "testdata/defaults",
+			structs: []string{"DefaultsOptions", "EmbeddedOptions"},
+		},
+		{
+			dir:     "testdata/external",
+			structs: []string{"ExternalOptions"},
+			options: []Option{SetDestination("testdata/external/setters"), SetShims(true)},
+		},
+		{
+			dir:     "testdata/fields",
+			structs: []string{"FieldsOptions"},
+			options: []Option{SetExclude("*cache")},
+		},
+		{
+			dir:     "testdata/imports",
+			structs: []string{"ImportsOptions"},
+		},
+		{
+			dir:     "testdata/merged",
+			structs: []string{"InnerOptions", "OuterOptions"},
+			options: []Option{SetOutputPattern("{name}_options.go")},
+		},
+		{
+			dir:     "testdata/presence",
+			structs: []string{"PresenceOptions"},
+		},
+		{
+			dir:     "testdata/validate",
+			structs: []string{"InnerOptions", "PointerOptions", "OuterOptions", "WrapperOptions"},
 		},
 	}
 
 	for _, tc := range tcs {
-		t.Run(tc.name, func(t *testing.T) {
-			basepath := writeSource(t, tc.gt.sources)
+		t.Run(tc.dir, func(t *testing.T) {
+			basepath, err := filepath.Abs(tc.dir)
+			if err != nil {
+				t.Fatalf("Failed to set up test; did not get absolute path: %s", err.Error())
+			}
 
 			l := loadSource(t, basepath)
 
-			g := NewGenerator(l, SetLog(l.Log))
+			g := NewGenerator(l, append([]Option{SetLog(l.Log)}, tc.options...)...)
 
-			parsedArg := Arg{
-				Source:     basepath,
-				StructName: "FooOptions",
+			args := make([]Arg, len(tc.structs))
+			for k, structName := range tc.structs {
+				args[k] = Arg{Source: basepath, StructName: structName}
 			}
 
-			var buf bytes.Buffer
-			err := g.Generate(parsedArg, &buf)
+			files, err := g.GenerateFiles(args...)
 			if err != nil {
-				t.Errorf("Unexpected error from Generate: %s", err.Error())
+				t.Fatalf("Unexpected error from GenerateFiles: %s", err.Error())
 			}
 
-			t.Logf("Generated file:\n%s\n", buf.String())
+			for _, f := range files {
+				if *update {
+					if err := ioutil.WriteFile(f.Path, f.Content, 0644); err != nil {
+						t.Fatalf("Failed to update fixture '%s': %s", f.Path, err.Error())
+					}
+					continue
+				}
 
-			astf := loadGeneratedCode(t, buf.Bytes())
-			evalulateGeneratedCode(t, astf, tc.gt.funcs)
+				expected, err := ioutil.ReadFile(f.Path)
+				if err != nil {
+					t.Fatalf("Failed to read fixture '%s': %s", f.Path, err.Error())
+				}
+				if !bytes.Equal(expected, f.Content) {
+					t.Errorf("Fixture '%s' is out of date; run `go test ./generate -run Test_Generate_Fixtures -update`", f.Path)
+				}
+			}
+		})
+	}
+}
+
+type failingOption struct {
+	target reflect.Type
+	err    error
+}
+
+func (fo *failingOption) TargetType() reflect.Type {
+	return fo.target
+}
+
+func (fo *failingOption) Apply(target interface{}) error {
+	return fo.err
+}
+
+func Test_Apply_Errors(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tcs := []struct {
+		name     string
+		optioner options.Optioner
+		opt      options.Option
+		expected error
+	}{
+		{
+			name:     "Static no embedded target",
+			optioner: &static.BarOptions{},
+			opt:      &failingOption{target: reflect.TypeOf(reflective.FooOptions{})},
+			expected: &options.ErrNoEmbeddedTarget{},
+		},
+		{
+			name:     "Static embedded failure",
+			optioner: &static.BarOptions{},
+			opt:      &failingOption{target: reflect.TypeOf(static.FooOptions{}), err: errFailed},
+			expected: &options.ErrEmbeddedApply{},
+		},
+		{
+			name:     "Static target mismatch",
+			optioner: &static.BarOptions{},
+			opt:      &failingOption{target: reflect.TypeOf(static.BarOptions{}), err: static.FooOptionsSetA("a").Apply(&static.BarOptions{})},
+			expected: &options.ErrTargetMismatch{},
+		},
+		{
+			name:     "Static same name in other package",
+			optioner: &static.BarOptions{},
+			opt:      static.OptionsSetF("f"),
+			expected: &options.ErrNoEmbeddedTarget{},
+		},
+		{
+			name:     "Reflective no embedded target",
+			optioner: &reflective.BarOptions{},
+			opt:      &failingOption{target: reflect.TypeOf(static.BarOptions{})},
+			expected: &options.ErrNoEmbeddedTarget{},
+		},
+		{
+			name:     "Reflective embedded failure",
+			optioner: &reflective.BarOptions{},
+			opt:      &failingOption{target: reflect.TypeOf(reflective.FooOptions{}), err: errFailed},
+			expected: &options.ErrEmbeddedApply{},
+		},
+		{
+			name:     "Reflective same name in other package",
+			optioner: &reflective.BarOptions{},
+			opt:      reflective.OptionsSetF("f"),
+			expected: &options.ErrNoEmbeddedTarget{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.optioner.Apply(tc.opt)
+			if err == nil {
+				t.Fatalf("Expected error from Apply")
+			}
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("Expected error of type %T, got %T: %s", tc.expected, err, err.Error())
+			}
+
+			var eea *options.ErrEmbeddedApply
+			if errors.As(err, &eea) {
+				if eea.Option != tc.opt {
+					t.Errorf("Expected error to carry the applied option")
+				}
+				if !errors.Is(err, errFailed) {
+					t.Errorf("Expected embedded error to be unwrapped; got %s", err.Error())
+				}
+			}
+		})
+	}
+}
+
+func Test_Apply_Embedded(t *testing.T) {
+	t.Run("Static", func(t *testing.T) {
+		bo := &static.BarOptions{}
+		err := bo.Apply(
+			static.FooOptionsSetA("a"),
+			static.BazOptionsSetC(true),
+			static.QuxOptionsSetD("d"),
+			other.OptionsSetE("e"),
+			static.BarOptionsSetB(1),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error from Apply: %s", err.Error())
+		}
+		if bo.QuxOptions == nil {
+			t.Errorf("Expected embedded pointer to be allocated")
+		}
+	})
+
+	t.Run("Reflective", func(t *testing.T) {
+		bo := &reflective.BarOptions{}
+		err := bo.Apply(
+			reflective.FooOptionsSetA("a"),
+			reflective.BazOptionsSetC(true),
+			reflective.QuxOptionsSetD("d"),
+			other.OptionsSetE("e"),
+			reflective.BarOptionsSetB(1),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error from Apply: %s", err.Error())
+		}
+		if bo.QuxOptions == nil {
+			t.Errorf("Expected embedded pointer to be allocated")
+		}
+	})
+
+	t.Run("Reflective unexported", func(t *testing.T) {
+		ho := &reflective.HiddenOptions{}
+		target := reflect.TypeOf(*ho).Field(0).Type
+		if err := ho.Apply(&failingOption{target: target}); err != nil {
+			t.Errorf("Unexpected error from Apply: %s", err.Error())
+		}
+	})
+}
+
+func Test_Validate(t *testing.T) {
+	tcs := []struct {
+		name     string
+		opts     []options.Option
+		expected string
+	}{
+		{
+			name: "Valid",
+			opts: []options.Option{
+				validate.InnerOptionsSetName("name"),
+				validate.OuterOptionsSetHost("example.com"),
+				validate.OuterOptionsSetPort(80),
+			},
+		},
+		{
+			name:     "Invalid",
+			opts:     []options.Option{validate.PointerOptionsSetCount(-1)},
+			expected: "OuterOptions.host: is required; OuterOptions.port: must be positive; InnerOptions.name: is required; PointerOptions.count: must not be negative",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			oo := &validate.OuterOptions{}
+			if err := oo.Apply(tc.opts...); err != nil {
+				t.Fatalf("Unexpected error from Apply: %s", err.Error())
+			}
+
+			var v options.Validator = oo
+			err := v.Validate()
+			if tc.expected == "" {
+				if err != nil {
+					t.Errorf("Unexpected error from Validate: %s", err.Error())
+				}
+				return
+			}
+
+			var ve options.ValidationErrors
+			if !errors.As(err, &ve) {
+				t.Fatalf("Expected options.ValidationErrors, got %T", err)
+			}
+			if err.Error() != tc.expected {
+				t.Errorf("Expected error:\n%s\ngot:\n%s", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func Test_Validate_Promoted(t *testing.T) {
+	wo := &validate.WrapperOptions{}
+	err := wo.Validate()
+	if err == nil {
+		t.Fatalf("Expected error from Validate")
+	}
+
+	// The embedded struct's `validate` is run once, by its own `Validate`.
+	expected := "InnerOptions.name: is required"
+	if err.Error() != expected {
+		t.Errorf("Expected error:\n%s\ngot:\n%s", expected, err.Error())
+	}
+}
+
+func Test_Defaults(t *testing.T) {
+	do, err := defaults.NewDefaultsOptions(defaults.DefaultsOptionsSetPort(9090))
+	if err != nil {
+		t.Fatalf("Unexpected error from NewDefaultsOptions: %s", err.Error())
+	}
+
+	if do.Host != "localhost" || do.Retries != -1 || do.Ratio != 0.5 || !do.Verbose || do.Name != "" {
+		t.Errorf("Defaults were not applied: %#v", do)
+	}
+	if do.Port != 9090 {
+		t.Errorf("Expected option to override default; got %d", do.Port)
+	}
+	if do.EmbeddedOptions != nil {
+		t.Fatalf("Expected nil embedded pointer")
+	}
+
+	err = do.Apply(&failingOption{target: reflect.TypeOf(defaults.EmbeddedOptions{})})
+	if err != nil {
+		t.Fatalf("Unexpected error from Apply: %s", err.Error())
+	}
+	if do.EmbeddedOptions == nil || do.EmbeddedOptions.Level != 3 {
+		t.Errorf("Expected embedded pointer to be allocated with defaults; got %#v", do.EmbeddedOptions)
+	}
+}
+
+func Test_Discover(t *testing.T) {
+	basepath := writeSource(t, map[string]string{
+		"foo.go":     "package foo\n\n// FooOptions is marked\n// generate-options\ntype FooOptions struct {\n  a string\n}\n\n// Unmarked is not\ntype Unmarked struct {\n  b string\n}\n",
+		"bar/bar.go": "package bar\n\n// generate-options\ntype BarOptions struct {\n  c string\n}\n",
+	})
+
+	l := loadSource(t, basepath)
+
+	tcs := []struct {
+		name      string
+		recursive bool
+		expected  []Arg
+	}{
+		{
+			name: "Package",
+			expected: []Arg{
+				{Source: basepath, StructName: "FooOptions"},
+			},
+		},
+		{
+			name:      "Recursive",
+			recursive: true,
+			expected: []Arg{
+				{Source: basepath, StructName: "FooOptions"},
+				{Source: filepath.Join(basepath, "bar"), StructName: "BarOptions"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Discover(l, basepath, tc.recursive)
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Errorf("Expected %#v, got %#v", tc.expected, actual)
+			}
 		})
 	}
 }
 
+func Test_External(t *testing.T) {
+	proxy := &url.URL{Scheme: "http", Host: "proxy:3128"}
+	eo, err := setters.NewExternalOptions(
+		setters.ExternalOptionsSetHost("localhost"),
+		setters.ExternalOptionsSetProxy(proxy),
+		setters.ExternalOptionsSetPort(8080),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewExternalOptions: %s", err.Error())
+	}
+	if eo.Host != "localhost" {
+		t.Errorf("Expected host 'localhost', got '%s'", eo.Host)
+	}
+	if eo.Proxy != proxy {
+		t.Errorf("Expected proxy '%s', got '%s'", proxy, eo.Proxy)
+	}
+	if eo.Port() != 8080 {
+		t.Errorf("Expected port 8080, got %d", eo.Port())
+	}
+	if eo.Timeout() != 5*time.Second {
+		t.Errorf("Expected default timeout 5s, got %s", eo.Timeout())
+	}
+}
+
+func Test_Fields(t *testing.T) {
+	fo, err := fields.NewFieldsOptions(fields.FieldsOptionsSetHostname("localhost"))
+	if err != nil {
+		t.Fatalf("Unexpected error from NewFieldsOptions: %s", err.Error())
+	}
+
+	// Fields which are skipped by tag or by pattern still get their defaults.
+	if fo.Retries() != 3 {
+		t.Errorf("Expected default retries 3, got %d", fo.Retries())
+	}
+	if !fo.AddrCache() {
+		t.Errorf("Expected default address cache to be enabled")
+	}
+}
+
+func Test_Merged(t *testing.T) {
+	oo, err := merged.NewOuterOptions(
+		merged.InnerOptionsSetName("inner"),
+		merged.OuterOptionsSetCount(2),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewOuterOptions: %s", err.Error())
+	}
+	if oo.Name() != "inner" {
+		t.Errorf("Expected name 'inner', got '%s'", oo.Name())
+	}
+	if oo.Count() != 2 {
+		t.Errorf("Expected count 2, got %d", oo.Count())
+	}
+}
+
+func Test_Presence(t *testing.T) {
+	po, err := presence.NewPresenceOptions(
+		presence.PresenceOptionsSetHost(""),
+		presence.PresenceOptionsAppendTags("a"),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewPresenceOptions: %s", err.Error())
+	}
+	if !po.IsSetHost() || !po.IsSet("host") {
+		t.Errorf("Expected host to be set to its zero value")
+	}
+	if po.IsSetPort() || po.IsSet("port") {
+		t.Errorf("Expected port to have only its default")
+	}
+	if po.IsSet("unknown") {
+		t.Errorf("Expected an unknown field not to be set")
+	}
+	if fields := po.SetFields(); !reflect.DeepEqual(fields, []string{"host", "tags"}) {
+		t.Errorf("Expected set fields 'host', 'tags', got %v", fields)
+	}
+}
+
+func Test_Convenience(t *testing.T) {
+	co, err := convenience.NewConvenienceOptions(
+		convenience.ConvenienceOptionsEnableVerbose(),
+		convenience.ConvenienceOptionsSetTimeoutFromString("1m30s"),
+		convenience.ConvenienceOptionsSetBufferFromString("64MiB"),
+		convenience.ConvenienceOptionsSetLimitFromString("2KB"),
+		convenience.ConvenienceOptionsSetRatioFromString("0.25"),
+		convenience.ConvenienceOptionsSetLevelFromString("-3"),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewConvenienceOptions: %s", err.Error())
+	}
+	expected := &convenience.ConvenienceOptions{
+		Verbose: true,
+		Timeout: 90 * time.Second,
+		Buffer:  64 << 20,
+		Limit:   2000,
+		Ratio:   0.25,
+		Level:   -3,
+	}
+	if !reflect.DeepEqual(co, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, co)
+	}
+
+	err = co.Apply(convenience.ConvenienceOptionsDisableVerbose())
+	if err != nil || co.Verbose {
+		t.Errorf("Expected verbose to be disabled, got %t (%v)", co.Verbose, err)
+	}
+
+	err = co.Apply(convenience.ConvenienceOptionsSetLevelFromString("128"))
+	var fe *options.FieldError
+	if !errors.As(err, &fe) || fe.Field != "Level" {
+		t.Errorf("Expected a field error for 'Level', got %v", err)
+	}
+}
+
+func Test_Imports(t *testing.T) {
+	_, err := importsfixture.NewImportsOptions(
+		importsfixture.ImportsOptionsSetClient(http.DefaultClient),
+		importsfixture.ImportsOptionsSetHeaders(map[string][]http.Header{}),
+		importsfixture.ImportsOptionsSetSettings(importsoptions.Settings{Name: "name"}),
+		importsfixture.ImportsOptionsSetTimeout(time.Second),
+	)
+	if err != nil {
+		t.Errorf("Unexpected error from NewImportsOptions: %s", err.Error())
+	}
+}
+
+func Benchmark_Apply_StaticDispatch(b *testing.B) {
+	bo := &static.BarOptions{}
+	opts := []options.Option{
+		static.FooOptionsSetA("a"),
+		static.BarOptionsSetB(1),
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := bo.Apply(opts...); err != nil {
+			b.Fatalf("Unexpected error from Apply: %s", err.Error())
+		}
+	}
+}
+
+func Benchmark_Apply_ReflectDispatch(b *testing.B) {
+	bo := &reflective.BarOptions{}
+	opts := []options.Option{
+		reflective.FooOptionsSetA("a"),
+		reflective.BarOptionsSetB(1),
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := bo.Apply(opts...); err != nil {
+			b.Fatalf("Unexpected error from Apply: %s", err.Error())
+		}
+	}
+}
+
 func writeSource(t *testing.T, sources map[string]string) string {
 	d, err := ioutil.TempDir("", uuid.New().String())
 	if err != nil {
@@ -139,7 +1342,7 @@ func loadSource(t *testing.T, basepath string) *loader.Loader {
 func loadGeneratedCode(t *testing.T, buf []byte) *ast.File {
 	fset := token.NewFileSet()
 
-	f, err := parser.ParseFile(fset, "src.go", buf, 0)
+	f, err := parser.ParseFile(fset, "src.go", buf, parser.ParseComments)
 	if err != nil {
 		t.Fatalf("Error while loading generated file")
 	}
@@ -151,7 +1354,7 @@ func loadGeneratedCode(t *testing.T, buf []byte) *ast.File {
 	return f
 }
 
-func evalulateGeneratedCode(t *testing.T, astf *ast.File, funcs map[string]string) {
+func evalulateGeneratedCode(t *testing.T, astf *ast.File, funcs map[string]string, receiver bool) {
 	found := map[string]bool{}
 
 	ast.Inspect(astf, func(n ast.Node) bool {
@@ -171,6 +1374,11 @@ func evalulateGeneratedCode(t *testing.T, astf *ast.File, funcs map[string]strin
 			return false
 		}
 
+		if hasReceiver := funcDecl.Recv != nil; hasReceiver != receiver {
+			t.Errorf("Found func '%s', expected receiver %t but got %t", funcDecl.Name.Name, receiver, hasReceiver)
+			return false
+		}
+
 		if funcDecl.Type.Params.NumFields() != 1 {
 			t.Errorf("Found func '%s', has %d parameters", funcDecl.Name.Name, funcDecl.Type.Params.NumFields())
 			return false
@@ -0,0 +1,111 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go/types"
+	"path/filepath"
+	"sort"
+
+	"github.com/object88/options/loader"
+)
+
+// inputHashAlgorithm prefixes the input hash, so that it can be changed
+// without old hashes being mistaken for new ones
+const inputHashAlgorithm = "sha256"
+
+// sourceFiles returns the absolute paths of the source files which declare
+// the output's structs, ordered by path
+func (out *output) sourceFiles() []string {
+	seen := map[string]bool{}
+	filenames := []string{}
+	for _, t := range out.targets {
+		if !seen[t.filename] {
+			seen[t.filename] = true
+			filenames = append(filenames, t.filename)
+		}
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
+// declaredType is a type whose declaration shapes the generated code
+type declaredType struct {
+	pkg  *loader.Package
+	name string
+}
+
+// declaredTypes returns the output's structs, followed by the options structs
+// they embed, once each.  The fields of embedded structs are not generated,
+// but their declarations still shape the generated code.
+func (g *Generator) declaredTypes(out *output) []declaredType {
+	seen := map[*types.TypeName]bool{}
+	results := []declaredType{}
+	for _, t := range out.targets {
+		seen[t.named.Obj()] = true
+		results = append(results, declaredType{pkg: t.pkg, name: t.name})
+	}
+
+	for _, t := range out.targets {
+		for _, e := range collectEmbedded(t.pkg.Types(), t.s) {
+			obj := e.named.Obj()
+			if seen[obj] {
+				continue
+			}
+			seen[obj] = true
+
+			p := t.pkg
+			if obj.Pkg() != t.pkg.Types() {
+				dir, err := g.l.FindImportDirectory(obj.Pkg().Path(), t.pkg.AbsPath)
+				if err != nil {
+					continue
+				}
+				if p, err = g.l.FindPackage(dir); err != nil {
+					continue
+				}
+			}
+			results = append(results, declaredType{pkg: p, name: obj.Name()})
+		}
+	}
+	return results
+}
+
+// createProvenance returns the source files of the output, relative to the
+// output's directory, and a hash of the declarations of its structs and of the
+// structs they embed, including their tags and comments.  A change to them
+// changes the hash, so stale output can be detected by comparing it with the
+// header of the generated file.  Other declarations in the same files are not
+// hashed, so that unrelated edits do not make the output stale.
+func (g *Generator) createProvenance(out *output) ([]string, string, error) {
+	dir := filepath.Dir(out.path)
+
+	sources := []string{}
+	for _, filename := range out.sourceFiles() {
+		sources = append(sources, relativeSource(dir, filename))
+	}
+
+	h := sha256.New()
+	for _, dt := range g.declaredTypes(out) {
+		filename, src, err := dt.pkg.FindDeclaration(dt.name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		h.Write([]byte(relativeSource(dir, filename)))
+		h.Write([]byte{0})
+		h.Write(src)
+		h.Write([]byte{0})
+	}
+
+	return sources, inputHashAlgorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// relativeSource returns the path of the source file relative to the
+// output's directory, with forward slashes
+func relativeSource(dir, filename string) string {
+	rel, err := filepath.Rel(dir, filename)
+	if err != nil {
+		rel = filepath.Base(filename)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,108 @@
+package generate
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/object88/options/templates"
+)
+
+// optionsImportPath is the import path of the package which defines
+// `options.Option`
+const optionsImportPath = "github.com/object88/options"
+
+// imports collects the packages referenced by the generated code and
+// assigns each a name which is unique within the generated file
+type imports struct {
+	path   string
+	byPath map[string]string
+	byName map[string]string
+}
+
+// newImports creates a new import collection for code generated into the
+// package with the provided import path.  The packages which the template
+// always uses are added first, so that they keep their own names.
+func newImports(importPath string, used ...string) *imports {
+	i := &imports{
+		path:   importPath,
+		byPath: map[string]string{},
+		byName: map[string]string{},
+	}
+	for _, u := range used {
+		i.add(u, path.Base(u))
+	}
+	return i
+}
+
+// add records the package, and returns the name by which the generated code
+// refers to it.  A package whose name is already taken is given a numbered
+// alias.
+func (i *imports) add(importPath, name string) string {
+	if n, ok := i.byPath[importPath]; ok {
+		return n
+	}
+
+	alias := name
+	for k := 2; ; k++ {
+		if _, ok := i.byName[alias]; !ok {
+			break
+		}
+		alias = fmt.Sprintf("%s%d", name, k)
+	}
+
+	i.byPath[importPath] = alias
+	i.byName[alias] = importPath
+	return alias
+}
+
+// qualifier is the implementation of types.Qualifier
+func (i *imports) qualifier(p *types.Package) string {
+	if p.Path() == i.path {
+		return ""
+	}
+	return i.add(p.Path(), p.Name())
+}
+
+// typeString returns the type as it must be written in the generated code
+func (i *imports) typeString(t types.Type) string {
+	return types.TypeString(t, i.qualifier)
+}
+
+// groups returns the imports for the template, with the standard library
+// in the first group and all other packages in the second.  An alias is
+// written only when the name differs from the last element of the path.
+func (i *imports) groups() [][]templates.ImportData {
+	std := []templates.ImportData{}
+	other := []templates.ImportData{}
+	for importPath, name := range i.byPath {
+		id := templates.ImportData{Path: importPath}
+		if name != path.Base(importPath) {
+			id.Alias = name
+		}
+		if isStandard(importPath) {
+			std = append(std, id)
+		} else {
+			other = append(other, id)
+		}
+	}
+
+	results := [][]templates.ImportData{}
+	for _, group := range [][]templates.ImportData{std, other} {
+		if len(group) == 0 {
+			continue
+		}
+		sort.Slice(group, func(a, b int) bool { return group[a].Path < group[b].Path })
+		results = append(results, group)
+	}
+	return results
+}
+
+// isStandard reports whether the import path belongs to the standard
+// library, whose first path element never contains a dot
+func isStandard(importPath string) bool {
+	first := strings.SplitN(importPath, "/", 2)[0]
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,100 @@
+package generate
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+)
+
+// methodLocals are the identifiers declared within the bodies of the
+// generated methods, which the receiver must not shadow
+var methodLocals = []string{"err", "errs", "field", "fields", "k", "name", "ok", "opt", "opts", "target"}
+
+// generatedMethods are the methods generated for every struct, which shims
+// must not collide with
+var generatedMethods = []string{"Apply", "ApplyDefaults", "Validate"}
+
+// namer chooses identifiers for the generated code which do not collide with
+// the names in scope where they are declared, with Go keywords, or with
+// predeclared identifiers
+type namer struct {
+	reserved map[string]bool
+
+	// variants are suffixes which form other identifiers from a chosen name,
+	// each of which must also be free
+	variants []string
+}
+
+// newNamer creates a namer with the provided names already in scope
+func newNamer(reserved ...string) *namer {
+	n := &namer{reserved: map[string]bool{}}
+	n.reserve(reserved...)
+	return n
+}
+
+// reserve marks the names as in scope
+func (n *namer) reserve(names ...string) {
+	for _, name := range names {
+		n.reserved[name] = true
+	}
+}
+
+// free reports whether the name, and each of its variants, may be declared
+func (n *namer) free(name string) bool {
+	for _, variant := range append([]string{""}, n.variants...) {
+		v := name + variant
+		if n.reserved[v] || token.Lookup(v).IsKeyword() || types.Universe.Lookup(v) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// name returns the first of the candidates which is free.  When none are,
+// the first candidate is numbered, as imports are.
+func (n *namer) name(candidates ...string) string {
+	for _, c := range candidates {
+		if n.free(c) {
+			return c
+		}
+	}
+	for k := 2; ; k++ {
+		c := fmt.Sprintf("%s%d", candidates[0], k)
+		if n.free(c) {
+			return c
+		}
+	}
+}
+
+// receiverNamer returns a namer for the receiver of the generated methods,
+// which is also the instance name in the setters.  The receiver must not
+// shadow the imports, the type variables, or the locals of the method bodies,
+// and the name of the setters' `Opt` variable is formed from it.
+func receiverNamer(t *target, imps *imports, tvs *typeVars) *namer {
+	n := newNamer(methodLocals...)
+	n.variants = []string{"o"}
+	for name := range imps.byName {
+		n.reserve(name)
+	}
+	for _, p := range t.pkg.Types().Imports() {
+		n.reserve(p.Name())
+	}
+	for _, tv := range tvs.vars {
+		n.reserve(tv.Name)
+	}
+	n.reserve(t.optName)
+	return n
+}
+
+// memberNamer returns a namer for the methods generated for the struct,
+// which must not collide with its fields, or with its hand-written methods
+func memberNamer(t *target) *namer {
+	n := newNamer(t.generatedMethods()...)
+	for i := 0; i < t.s.NumFields(); i++ {
+		n.reserve(t.s.Field(i).Name())
+	}
+	for i := 0; i < t.user.NumMethods(); i++ {
+		n.reserve(t.user.Method(i).Name())
+	}
+	return n
+}
@@ -0,0 +1,59 @@
+package generate
+
+import "testing"
+
+func Test_Namer(t *testing.T) {
+	tcs := []struct {
+		name       string
+		reserved   []string
+		variants   []string
+		candidates []string
+		expected   string
+	}{
+		{
+			name:       "Free",
+			candidates: []string{"fo"},
+			expected:   "fo",
+		},
+		{
+			name:       "Reserved",
+			reserved:   []string{"fo"},
+			candidates: []string{"fo", "fOther"},
+			expected:   "fOther",
+		},
+		{
+			name:       "Keyword",
+			candidates: []string{"type"},
+			expected:   "type2",
+		},
+		{
+			name:       "Predeclared",
+			candidates: []string{"len", "len"},
+			expected:   "len2",
+		},
+		{
+			name:       "Numbered",
+			reserved:   []string{"ok", "ok2"},
+			candidates: []string{"ok"},
+			expected:   "ok3",
+		},
+		{
+			name:       "Variant",
+			reserved:   []string{"foo"},
+			variants:   []string{"o"},
+			candidates: []string{"fo"},
+			expected:   "fo2",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			n := newNamer(tc.reserved...)
+			n.variants = tc.variants
+			actual := n.name(tc.candidates...)
+			if actual != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -11,8 +11,117 @@ func SetLog(l log.Logger) Option {
 	}
 }
 
+// SetDestination determines the directory into which the option setters are
+// generated.  When it is not the directory of the options struct, the methods
+// which must be declared alongside the struct are still generated there.
 func SetDestination(d string) Option {
 	return func(g *Generator) error {
+		g.destination = d
+		return nil
+	}
+}
+
+// SetPackage determines the name of the package into which the option
+// setters are generated, when it is not the package of the options struct.
+// By default, the name of an existing package in the destination is used,
+// or else the name of the destination directory.
+func SetPackage(p string) Option {
+	return func(g *Generator) error {
+		g.packageName = p
+		return nil
+	}
+}
+
+// SetShims determines whether unexported fields of a struct generated into
+// another package are reached through exported accessor methods generated in
+// the struct's package.  Without shims, such fields are an error.
+func SetShims(s bool) Option {
+	return func(g *Generator) error {
+		g.shims = s
+		return nil
+	}
+}
+
+// SetStyle determines the shape of the generated option setters
+func SetStyle(s Style) Option {
+	return func(g *Generator) error {
+		g.style = s
+		return nil
+	}
+}
+
+// SetPrefix determines the prefix of the generated option setters, such as
+// `Set` or `With`
+func SetPrefix(p string) Option {
+	return func(g *Generator) error {
+		g.prefix = p
+		return nil
+	}
+}
+
+// SetDispatch determines how the generated `Apply` routes options to
+// embedded structs
+func SetDispatch(d Dispatch) Option {
+	return func(g *Generator) error {
+		g.dispatch = d
+		return nil
+	}
+}
+
+// SetConflict determines what is generated in place of a setter or
+// constructor whose name is already declared by hand
+func SetConflict(c Conflict) Option {
+	return func(g *Generator) error {
+		g.conflict = c
+		return nil
+	}
+}
+
+// SetOutputPattern determines the names of the generated files.  Each
+// `{name}` in the pattern is replaced by the name of the source file which
+// declares the structs, without its extension.
+func SetOutputPattern(p string) Option {
+	return func(g *Generator) error {
+		g.outputPattern = p
+		return nil
+	}
+}
+
+// SetTemplate replaces the bundled template with the provided template
+// source.  Templates are executed with `templates.Data`, and may use the
+// functions in `FuncMap`.
+func SetTemplate(src string) Option {
+	return func(g *Generator) error {
+		g.templateSrc = src
+		return nil
+	}
+}
+
+// SetTemplateFile replaces the bundled template with the template in the
+// file, which is read when the code is generated.  An empty path keeps the
+// bundled template.
+func SetTemplateFile(path string) Option {
+	return func(g *Generator) error {
+		g.templatePath = path
+		return nil
+	}
+}
+
+// SetInclude restricts the setters to the fields whose names match one of
+// the glob patterns, such as `Host*`
+func SetInclude(patterns ...string) Option {
+	return func(g *Generator) error {
+		g.include = patterns
+		return nil
+	}
+}
+
+// SetExclude skips the setters of the fields whose names match one of the
+// glob patterns, such as `*cache`.  A field may also be skipped with an
+// `options:"-"` tag.
+func SetExclude(patterns ...string) Option {
+	return func(g *Generator) error {
+		g.exclude = patterns
 		return nil
 	}
 }
@@ -0,0 +1,66 @@
+package generate
+
+import (
+	"go/types"
+
+	"github.com/object88/options"
+	"github.com/pkg/errors"
+)
+
+// presenceTypeName is the name of the type in the options package which
+// records the fields set by options
+const presenceTypeName = "Presence"
+
+// presenceMethods are the methods generated for structs which track the
+// presence of their fields
+var presenceMethods = []string{"IsSet", "SetFields"}
+
+// isPresence reports whether `t` is `options.Presence`
+func isPresence(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok || n.Obj().Pkg() == nil {
+		return false
+	}
+	return n.Obj().Pkg().Path() == optionsImportPath && n.Obj().Name() == presenceTypeName
+}
+
+// findPresence returns the field of the struct which records the presence of
+// its other fields, if it has one
+func findPresence(structName string, s *types.Struct) (*types.Var, error) {
+	var presence *types.Var
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if !isPresence(f.Type()) {
+			continue
+		}
+		if f.Anonymous() {
+			return nil, errors.Errorf("Struct '%s' embeds 'options.%s', whose methods would collide with the generated ones; name the field", structName, presenceTypeName)
+		}
+		if presence != nil {
+			return nil, errors.Errorf("Struct '%s' has more than one 'options.%s' field", structName, presenceTypeName)
+		}
+		presence = f
+	}
+	return presence, nil
+}
+
+// generatedMethods returns the names of the methods generated for the
+// struct
+func (t *target) generatedMethods() []string {
+	if t.presence == nil {
+		return generatedMethods
+	}
+	return append(append([]string{}, generatedMethods...), presenceMethods...)
+}
+
+// checkPresence reports whether the presence of each of the struct's fields
+// can be recorded
+func checkPresence(t *target, fields []setterField) error {
+	if t.presence == nil {
+		return nil
+	}
+	if len(fields) > options.PresenceFields {
+		return errors.Errorf("Struct '%s' has %d fields with setters, but the presence of at most %d can be recorded", t.name, len(fields), options.PresenceFields)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package generate
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/object88/options"
+)
+
+func Test_CheckPresence(t *testing.T) {
+	tcs := []struct {
+		name   string
+		fields int
+		err    bool
+	}{
+		{
+			name:   "Most fields",
+			fields: options.PresenceFields,
+		},
+		{
+			name:   "Too many fields",
+			fields: options.PresenceFields + 1,
+			err:    true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			presence := types.NewField(token.NoPos, nil, "presence", types.Typ[types.Int], false)
+			target := &target{name: "FooOptions", presence: presence}
+
+			err := checkPresence(target, make([]setterField, tc.fields))
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error from checkPresence")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error from checkPresence: %s", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Style describes the shape of the generated option setters
+type Style int
+
+const (
+	// StructStyle generates package-level funcs named after the struct, such
+	// as `FooOptionsSetA`
+	StructStyle Style = iota
+
+	// PrefixStyle generates package-level funcs named with only the prefix and
+	// the field, such as `SetA` or `WithA`
+	PrefixStyle
+
+	// MethodStyle generates methods on the struct, such as `(*FooOptions).SetA`
+	MethodStyle
+)
+
+var styleNames = map[Style]string{
+	StructStyle: "struct",
+	PrefixStyle: "prefix",
+	MethodStyle: "method",
+}
+
+// ParseStyle returns the Style for the provided name
+func ParseStyle(name string) (Style, error) {
+	for s, n := range styleNames {
+		if strings.EqualFold(n, name) {
+			return s, nil
+		}
+	}
+	return StructStyle, errors.Errorf("Unknown style '%s'; expected one of 'struct', 'prefix', or 'method'", name)
+}
+
+// String is the implementation of fmt.Stringer
+func (s Style) String() string {
+	return styleNames[s]
+}
+
+// setterName returns the name of the setter for the provided struct and
+// capitalized field name
+func (s Style) setterName(structName, prefix, capitalizedName string) string {
+	if s == StructStyle {
+		return structName + prefix + capitalizedName
+	}
+	return prefix + capitalizedName
+}
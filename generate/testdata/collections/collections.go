@@ -0,0 +1,16 @@
+package collections
+
+import "time"
+
+// Labels is a named map type
+type Labels map[string]string
+
+// CollectionsOptions has fields which may be layered by several options
+type CollectionsOptions struct {
+	// Hosts are the servers to connect to
+	Hosts    []string
+	backoffs []time.Duration
+	labels   Labels
+	limits   map[string]int
+	data     [4]byte
+}
@@ -0,0 +1,18 @@
+package convenience
+
+import "time"
+
+// Level is a named numeric type
+type Level int8
+
+// ConvenienceOptions has fields which may be set from human input
+type ConvenienceOptions struct {
+	Verbose  bool
+	Timeout  time.Duration
+	Buffer   uint64 `options:"size"`
+	Limit    int32  `options:"size"`
+	Ratio    float32
+	Retries  int
+	Level    Level
+	Attempts int64
+}
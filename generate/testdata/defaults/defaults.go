@@ -0,0 +1,12 @@
+package defaults
+
+// DefaultsOptions has a default for each supported kind of field
+type DefaultsOptions struct {
+	*EmbeddedOptions
+	Host    string  `default:"localhost"`
+	Port    uint16  `default:"8080"`
+	Retries int     `default:"-1"`
+	Ratio   float64 `default:"0.5"`
+	Verbose bool    `default:"true"`
+	Name    string
+}
@@ -0,0 +1,6 @@
+package defaults
+
+// EmbeddedOptions is embedded by DefaultsOptions by pointer
+type EmbeddedOptions struct {
+	Level int `default:"3"`
+}
@@ -0,0 +1,6 @@
+package other
+
+// Options shares its name with options structs in other packages
+type Options struct {
+	e string
+}
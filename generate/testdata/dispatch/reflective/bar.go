@@ -0,0 +1,12 @@
+package reflective
+
+import "github.com/object88/options/generate/testdata/dispatch/other"
+
+// BarOptions embeds FooOptions, QuxOptions by pointer, and an options struct
+// from another package
+type BarOptions struct {
+	FooOptions
+	*QuxOptions
+	other.Options
+	b int
+}
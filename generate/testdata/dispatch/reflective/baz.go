@@ -0,0 +1,6 @@
+package reflective
+
+// BazOptions is embedded by FooOptions
+type BazOptions struct {
+	c bool
+}
@@ -0,0 +1,7 @@
+package reflective
+
+// FooOptions is embedded by BarOptions, and embeds BazOptions
+type FooOptions struct {
+	BazOptions
+	a string
+}
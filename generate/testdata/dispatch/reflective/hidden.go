@@ -0,0 +1,12 @@
+package reflective
+
+// HiddenOptions embeds an options struct of an unexported type, which
+// reflection alone cannot set
+type HiddenOptions struct {
+	hiddenOptions
+}
+
+// hiddenOptions is embedded by HiddenOptions
+type hiddenOptions struct {
+	h string
+}
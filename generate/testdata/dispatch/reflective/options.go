@@ -0,0 +1,6 @@
+package reflective
+
+// Options shares its name with options structs in other packages
+type Options struct {
+	f string
+}
@@ -0,0 +1,6 @@
+package static
+
+// BazOptions is embedded by FooOptions
+type BazOptions struct {
+	c bool
+}
@@ -0,0 +1,114 @@
+// Code generated by options; DO NOT EDIT.
+//
+// Version: dev
+// Sources: baz.go
+// Structs: BazOptions
+// Input hash: sha256:323ef9b1a0e26f03209c23ba44673a5ccc9fd2ce100738d4a7624e31e29337a2
+
+package static
+
+import (
+	"reflect"
+
+	"github.com/object88/options"
+)
+
+var (
+	bazOptionsType = reflect.TypeOf(BazOptions{})
+)
+
+// BazOptionsSetC generates an options.Option for use with
+// `Apply` to set BazOptions.c
+func BazOptionsSetC(c bool) options.Option {
+	boo := BazOptionsOpt{
+		F: func(bo *BazOptions) error {
+			bo.c = c
+			return nil
+		},
+	}
+	return &boo
+}
+
+// BazOptionsEnableC generates an options.Option for use with
+// `Apply` to set BazOptions.c to true
+func BazOptionsEnableC() options.Option {
+	boo := BazOptionsOpt{
+		F: func(bo *BazOptions) error {
+			bo.c = true
+			return nil
+		},
+	}
+	return &boo
+}
+
+// BazOptionsDisableC generates an options.Option for use with
+// `Apply` to set BazOptions.c to false
+func BazOptionsDisableC() options.Option {
+	boo := BazOptionsOpt{
+		F: func(bo *BazOptions) error {
+			bo.c = false
+			return nil
+		},
+	}
+	return &boo
+}
+
+// NewBazOptions returns a new `*BazOptions` with its defaults set and
+// the provided options applied, once it has been validated.
+func NewBazOptions(opts ...options.Option) (*BazOptions, error) {
+	bo := &BazOptions{}
+	bo.ApplyDefaults()
+	if err := bo.Apply(opts...); err != nil {
+		return nil, err
+	}
+	if err := bo.Validate(); err != nil {
+		return nil, err
+	}
+	return bo, nil
+}
+
+// ApplyDefaults sets each field of `*BazOptions` which has a `default`
+// tag, and the defaults of each of its embedded options structs.
+func (bo *BazOptions) ApplyDefaults() {
+}
+
+// Apply accepts a number of Option funcs and uses them to modify the supplied
+// `*BazOptions`.
+//
+// BazOptions is embedded by FooOptions
+func (bo *BazOptions) Apply(opts ...options.Option) error {
+	for _, opt := range opts {
+		switch opt.TargetType() {
+		case bazOptionsType:
+			if err := opt.Apply(bo); err != nil {
+				return err
+			}
+		default:
+			return &options.ErrNoEmbeddedTarget{Target: opt.TargetType(), Option: opt}
+		}
+	}
+	return nil
+}
+
+// Validate checks the values of `*BazOptions` and each of its embedded
+// options structs, and returns every failure as `options.ValidationErrors`.
+func (bo *BazOptions) Validate() error {
+	var errs options.ValidationErrors
+	return errs.ErrorOrNil()
+}
+
+type BazOptionsOpt struct {
+	F func(bo *BazOptions) error
+}
+
+func (boo *BazOptionsOpt) TargetType() reflect.Type {
+	return bazOptionsType
+}
+
+func (boo *BazOptionsOpt) Apply(target interface{}) error {
+	bo, ok := target.(*BazOptions)
+	if !ok {
+		return &options.ErrTargetMismatch{Target: reflect.TypeOf(target), Option: boo}
+	}
+	return boo.F(bo)
+}
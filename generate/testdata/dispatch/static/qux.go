@@ -0,0 +1,6 @@
+package static
+
+// QuxOptions is embedded by BarOptions by pointer
+type QuxOptions struct {
+	d string
+}
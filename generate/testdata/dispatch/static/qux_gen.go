@@ -0,0 +1,90 @@
+// Code generated by options; DO NOT EDIT.
+//
+// Version: dev
+// Sources: qux.go
+// Structs: QuxOptions
+// Input hash: sha256:e40b8df688442bf5eeeb8c2e6dca66917b10eba7613cd902b12381b7acf176f3
+
+package static
+
+import (
+	"reflect"
+
+	"github.com/object88/options"
+)
+
+var (
+	quxOptionsType = reflect.TypeOf(QuxOptions{})
+)
+
+// QuxOptionsSetD generates an options.Option for use with
+// `Apply` to set QuxOptions.d
+func QuxOptionsSetD(d string) options.Option {
+	qoo := QuxOptionsOpt{
+		F: func(qo *QuxOptions) error {
+			qo.d = d
+			return nil
+		},
+	}
+	return &qoo
+}
+
+// NewQuxOptions returns a new `*QuxOptions` with its defaults set and
+// the provided options applied, once it has been validated.
+func NewQuxOptions(opts ...options.Option) (*QuxOptions, error) {
+	qo := &QuxOptions{}
+	qo.ApplyDefaults()
+	if err := qo.Apply(opts...); err != nil {
+		return nil, err
+	}
+	if err := qo.Validate(); err != nil {
+		return nil, err
+	}
+	return qo, nil
+}
+
+// ApplyDefaults sets each field of `*QuxOptions` which has a `default`
+// tag, and the defaults of each of its embedded options structs.
+func (qo *QuxOptions) ApplyDefaults() {
+}
+
+// Apply accepts a number of Option funcs and uses them to modify the supplied
+// `*QuxOptions`.
+//
+// QuxOptions is embedded by BarOptions by pointer
+func (qo *QuxOptions) Apply(opts ...options.Option) error {
+	for _, opt := range opts {
+		switch opt.TargetType() {
+		case quxOptionsType:
+			if err := opt.Apply(qo); err != nil {
+				return err
+			}
+		default:
+			return &options.ErrNoEmbeddedTarget{Target: opt.TargetType(), Option: opt}
+		}
+	}
+	return nil
+}
+
+// Validate checks the values of `*QuxOptions` and each of its embedded
+// options structs, and returns every failure as `options.ValidationErrors`.
+func (qo *QuxOptions) Validate() error {
+	var errs options.ValidationErrors
+	return errs.ErrorOrNil()
+}
+
+type QuxOptionsOpt struct {
+	F func(qo *QuxOptions) error
+}
+
+func (qoo *QuxOptionsOpt) TargetType() reflect.Type {
+	return quxOptionsType
+}
+
+func (qoo *QuxOptionsOpt) Apply(target interface{}) error {
+	qo, ok := target.(*QuxOptions)
+	if !ok {
+		return &options.ErrTargetMismatch{Target: reflect.TypeOf(target), Option: qoo}
+	}
+	return qoo.F(qo)
+}
@@ -0,0 +1,25 @@
+package external
+
+import (
+	"net/url"
+	"time"
+)
+
+// ExternalOptions has its setters generated into the `setters` package
+type ExternalOptions struct {
+	// Host is the name of the server
+	Host    string
+	Proxy   *url.URL      // Proxy is the proxy through which the server is reached
+	port    int           // port is the port of the server
+	timeout time.Duration `default:"5s"`
+}
+
+// Port returns the unexported port
+func (eo *ExternalOptions) Port() int {
+	return eo.port
+}
+
+// Timeout returns the unexported timeout
+func (eo *ExternalOptions) Timeout() time.Duration {
+	return eo.timeout
+}
@@ -0,0 +1,78 @@
+// Code generated by options; DO NOT EDIT.
+//
+// Version: dev
+// Sources: external.go
+// Structs: ExternalOptions
+// Input hash: sha256:e9ff8cdc87e5d04bf06d1dd9e2218258d77d994550de0765b18ec6472ae56e2b
+
+package external
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/object88/options"
+)
+
+var (
+	externalOptionsType = reflect.TypeOf(ExternalOptions{})
+)
+
+// SetPort sets ExternalOptions.port for the option setters
+// generated in another package
+func (eo *ExternalOptions) SetPort(p int) {
+	eo.port = p
+}
+
+// SetTimeout sets ExternalOptions.timeout for the option setters
+// generated in another package
+func (eo *ExternalOptions) SetTimeout(t time.Duration) {
+	eo.timeout = t
+}
+
+// ApplyDefaults sets each field of `*ExternalOptions` which has a `default`
+// tag, and the defaults of each of its embedded options structs.
+func (eo *ExternalOptions) ApplyDefaults() {
+	eo.timeout = 5000000000
+}
+
+// Apply accepts a number of Option funcs and uses them to modify the supplied
+// `*ExternalOptions`.
+//
+// ExternalOptions has its setters generated into the `setters` package
+func (eo *ExternalOptions) Apply(opts ...options.Option) error {
+	for _, opt := range opts {
+		switch opt.TargetType() {
+		case externalOptionsType:
+			if err := opt.Apply(eo); err != nil {
+				return err
+			}
+		default:
+			return &options.ErrNoEmbeddedTarget{Target: opt.TargetType(), Option: opt}
+		}
+	}
+	return nil
+}
+
+// Validate checks the values of `*ExternalOptions` and each of its embedded
+// options structs, and returns every failure as `options.ValidationErrors`.
+func (eo *ExternalOptions) Validate() error {
+	var errs options.ValidationErrors
+	return errs.ErrorOrNil()
+}
+
+type ExternalOptionsOpt struct {
+	F func(eo *ExternalOptions) error
+}
+
+func (eoo *ExternalOptionsOpt) TargetType() reflect.Type {
+	return externalOptionsType
+}
+
+func (eoo *ExternalOptionsOpt) Apply(target interface{}) error {
+	eo, ok := target.(*ExternalOptions)
+	if !ok {
+		return &options.ErrTargetMismatch{Target: reflect.TypeOf(target), Option: eoo}
+	}
+	return eoo.F(eo)
+}
@@ -0,0 +1,118 @@
+// Code generated by options; DO NOT EDIT.
+//
+// Version: dev
+// Sources: ../external.go
+// Structs: ExternalOptions
+// Input hash: sha256:a0cfeb6f39d01aaa19bdb516f8cf6c6e60cb6f499fba408b140a693324aba739
+
+package setters
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/object88/options"
+	"github.com/object88/options/generate/testdata/external"
+)
+
+// ExternalOptionsSetHost generates an options.Option for use with
+// `Apply` to set ExternalOptions.Host
+//
+// Host is the name of the server
+func ExternalOptionsSetHost(H string) options.Option {
+	eoo := external.ExternalOptionsOpt{
+		F: func(eo *external.ExternalOptions) error {
+			eo.Host = H
+			return nil
+		},
+	}
+	return &eoo
+}
+
+// ExternalOptionsSetProxy generates an options.Option for use with
+// `Apply` to set ExternalOptions.Proxy
+//
+// Proxy is the proxy through which the server is reached
+func ExternalOptionsSetProxy(P *url.URL) options.Option {
+	eoo := external.ExternalOptionsOpt{
+		F: func(eo *external.ExternalOptions) error {
+			eo.Proxy = P
+			return nil
+		},
+	}
+	return &eoo
+}
+
+// ExternalOptionsSetPort generates an options.Option for use with
+// `Apply` to set ExternalOptions.port
+//
+// port is the port of the server
+func ExternalOptionsSetPort(p int) options.Option {
+	eoo := external.ExternalOptionsOpt{
+		F: func(eo *external.ExternalOptions) error {
+			eo.SetPort(p)
+			return nil
+		},
+	}
+	return &eoo
+}
+
+// ExternalOptionsSetPortFromString generates an options.Option for use with
+// `Apply` to set ExternalOptions.port from a string.  `Apply`
+// returns an error if the string cannot be parsed.
+func ExternalOptionsSetPortFromString(s string) options.Option {
+	eoo := external.ExternalOptionsOpt{
+		F: func(eo *external.ExternalOptions) error {
+			v, err := options.ParseInt(s, 0)
+			if err != nil {
+				return &options.FieldError{Field: "port", Err: err}
+			}
+			eo.SetPort(int(v))
+			return nil
+		},
+	}
+	return &eoo
+}
+
+// ExternalOptionsSetTimeout generates an options.Option for use with
+// `Apply` to set ExternalOptions.timeout
+func ExternalOptionsSetTimeout(t time.Duration) options.Option {
+	eoo := external.ExternalOptionsOpt{
+		F: func(eo *external.ExternalOptions) error {
+			eo.SetTimeout(t)
+			return nil
+		},
+	}
+	return &eoo
+}
+
+// ExternalOptionsSetTimeoutFromString generates an options.Option for use with
+// `Apply` to set ExternalOptions.timeout from a string.  `Apply`
+// returns an error if the string cannot be parsed.
+func ExternalOptionsSetTimeoutFromString(s string) options.Option {
+	eoo := external.ExternalOptionsOpt{
+		F: func(eo *external.ExternalOptions) error {
+			v, err := options.ParseDuration(s)
+			if err != nil {
+				return &options.FieldError{Field: "timeout", Err: err}
+			}
+			eo.SetTimeout(v)
+			return nil
+		},
+	}
+	return &eoo
+}
+
+// NewExternalOptions returns a new `*external.ExternalOptions` with its defaults set and
+// the provided options applied, once it has been validated.
+func NewExternalOptions(opts ...options.Option) (*external.ExternalOptions, error) {
+	eo := &external.ExternalOptions{}
+	eo.ApplyDefaults()
+	if err := eo.Apply(opts...); err != nil {
+		return nil, err
+	}
+	if err := eo.Validate(); err != nil {
+		return nil, err
+	}
+	return eo, nil
+}
@@ -0,0 +1,23 @@
+package fields
+
+import "sync"
+
+// FieldsOptions skips and renames fields by tag and by name pattern
+type FieldsOptions struct {
+	host      string `options:"name=Hostname"`
+	Port      int
+	mu        sync.Mutex `options:"-"`
+	cache     map[string]string
+	retries   int  `options:"-" default:"3"`
+	addrcache bool `default:"true"`
+}
+
+// Retries returns the number of retries, which has no setter
+func (fo *FieldsOptions) Retries() int {
+	return fo.retries
+}
+
+// AddrCache reports whether addresses are cached, which has no setter
+func (fo *FieldsOptions) AddrCache() bool {
+	return fo.addrcache
+}
@@ -0,0 +1,16 @@
+package imports
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/object88/options/generate/testdata/imports/options"
+)
+
+// ImportsOptions has fields whose types are declared in other packages
+type ImportsOptions struct {
+	client   *http.Client
+	headers  map[string][]http.Header
+	settings options.Settings
+	timeout  time.Duration `default:"5s"`
+}
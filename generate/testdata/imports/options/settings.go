@@ -0,0 +1,7 @@
+package options
+
+// Settings is declared in a package which shares its name with the root
+// options package
+type Settings struct {
+	Name string
+}
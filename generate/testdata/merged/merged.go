@@ -0,0 +1,22 @@
+package merged
+
+// InnerOptions is declared in the same file as the struct which embeds it
+type InnerOptions struct {
+	name string
+}
+
+// Name returns the unexported name
+func (io *InnerOptions) Name() string {
+	return io.name
+}
+
+// OuterOptions shares a generated file with InnerOptions
+type OuterOptions struct {
+	InnerOptions
+	count int
+}
+
+// Count returns the unexported count
+func (oo *OuterOptions) Count() int {
+	return oo.count
+}
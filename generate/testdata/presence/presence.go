@@ -0,0 +1,18 @@
+package presence
+
+import "github.com/object88/options"
+
+// PresenceOptions records which of its fields have been set
+type PresenceOptions struct {
+	presence options.Presence
+
+	host    string
+	port    int `default:"80"`
+	verbose bool
+	tags    []string
+}
+
+// Host returns the host
+func (po *PresenceOptions) Host() string {
+	return po.host
+}
@@ -0,0 +1,19 @@
+package validate
+
+import (
+	"errors"
+
+	"github.com/object88/options"
+)
+
+// InnerOptions is embedded by OuterOptions
+type InnerOptions struct {
+	name string
+}
+
+func (in *InnerOptions) validate() error {
+	if in.name == "" {
+		return &options.FieldError{Field: "name", Err: errors.New("is required")}
+	}
+	return nil
+}
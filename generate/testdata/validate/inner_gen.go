@@ -0,0 +1,91 @@
+// Code generated by options; DO NOT EDIT.
+//
+// Version: dev
+// Sources: inner.go
+// Structs: InnerOptions
+// Input hash: sha256:5aae320f859f4823c851bf485b180595ef3b82e2c54c7534cf6d1dac2f73ab8b
+
+package validate
+
+import (
+	"reflect"
+
+	"github.com/object88/options"
+)
+
+var (
+	innerOptionsType = reflect.TypeOf(InnerOptions{})
+)
+
+// InnerOptionsSetName generates an options.Option for use with
+// `Apply` to set InnerOptions.name
+func InnerOptionsSetName(n string) options.Option {
+	ioo := InnerOptionsOpt{
+		F: func(io *InnerOptions) error {
+			io.name = n
+			return nil
+		},
+	}
+	return &ioo
+}
+
+// NewInnerOptions returns a new `*InnerOptions` with its defaults set and
+// the provided options applied, once it has been validated.
+func NewInnerOptions(opts ...options.Option) (*InnerOptions, error) {
+	io := &InnerOptions{}
+	io.ApplyDefaults()
+	if err := io.Apply(opts...); err != nil {
+		return nil, err
+	}
+	if err := io.Validate(); err != nil {
+		return nil, err
+	}
+	return io, nil
+}
+
+// ApplyDefaults sets each field of `*InnerOptions` which has a `default`
+// tag, and the defaults of each of its embedded options structs.
+func (io *InnerOptions) ApplyDefaults() {
+}
+
+// Apply accepts a number of Option funcs and uses them to modify the supplied
+// `*InnerOptions`.
+//
+// InnerOptions is embedded by OuterOptions
+func (io *InnerOptions) Apply(opts ...options.Option) error {
+	for _, opt := range opts {
+		switch opt.TargetType() {
+		case innerOptionsType:
+			if err := opt.Apply(io); err != nil {
+				return err
+			}
+		default:
+			return &options.ErrNoEmbeddedTarget{Target: opt.TargetType(), Option: opt}
+		}
+	}
+	return nil
+}
+
+// Validate checks the values of `*InnerOptions` and each of its embedded
+// options structs, and returns every failure as `options.ValidationErrors`.
+func (io *InnerOptions) Validate() error {
+	var errs options.ValidationErrors
+	errs = errs.Append("InnerOptions", io.validate())
+	return errs.ErrorOrNil()
+}
+
+type InnerOptionsOpt struct {
+	F func(io *InnerOptions) error
+}
+
+func (ioo *InnerOptionsOpt) TargetType() reflect.Type {
+	return innerOptionsType
+}
+
+func (ioo *InnerOptionsOpt) Apply(target interface{}) error {
+	io, ok := target.(*InnerOptions)
+	if !ok {
+		return &options.ErrTargetMismatch{Target: reflect.TypeOf(target), Option: ioo}
+	}
+	return ioo.F(io)
+}
@@ -0,0 +1,26 @@
+package validate
+
+import (
+	"errors"
+
+	"github.com/object88/options"
+)
+
+// OuterOptions embeds InnerOptions, and PointerOptions by pointer
+type OuterOptions struct {
+	InnerOptions
+	*PointerOptions
+	host string
+	port int
+}
+
+func (oo *OuterOptions) validate() error {
+	var errs options.ValidationErrors
+	if oo.host == "" {
+		errs = append(errs, &options.ValidationError{Field: "host", Err: errors.New("is required")})
+	}
+	if oo.port <= 0 {
+		errs = append(errs, &options.ValidationError{Field: "port", Err: errors.New("must be positive")})
+	}
+	return errs.ErrorOrNil()
+}
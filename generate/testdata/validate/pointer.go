@@ -0,0 +1,19 @@
+package validate
+
+import (
+	"errors"
+
+	"github.com/object88/options"
+)
+
+// PointerOptions is embedded by OuterOptions by pointer
+type PointerOptions struct {
+	count int
+}
+
+func (po *PointerOptions) validate() error {
+	if po.count < 0 {
+		return &options.FieldError{Field: "count", Err: errors.New("must not be negative")}
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package validate
+
+// WrapperOptions embeds InnerOptions, and has no validation of its own
+type WrapperOptions struct {
+	InnerOptions
+	label string
+}
@@ -0,0 +1,96 @@
+package generate
+
+import (
+	"go/types"
+
+	"github.com/object88/options/templates"
+)
+
+// typeDataDepth limits how far the element types of a type are described,
+// so that recursive types terminate
+const typeDataDepth = 3
+
+// typeData describes the type for the template.  Only the type itself is
+// imported; the types it is built from are written with the names they
+// would have, so that describing them does not add unused imports.
+func (i *imports) typeData(t types.Type) templates.TypeData {
+	td := i.describeType(t, typeDataDepth)
+	td.String = i.typeString(t)
+	return td
+}
+
+// peekTypeData describes the type for the template without importing it, for
+// fields whose type the generated code does not write
+func (i *imports) peekTypeData(t types.Type) templates.TypeData {
+	return i.describeType(t, typeDataDepth)
+}
+
+// peekTypeString returns the type as it would be written in the generated
+// code, without importing it
+func (i *imports) peekTypeString(t types.Type) string {
+	return types.TypeString(t, i.peekQualifier)
+}
+
+func (i *imports) describeType(t types.Type, depth int) templates.TypeData {
+	td := templates.TypeData{
+		String:     i.peekTypeString(t),
+		Underlying: types.TypeString(t.Underlying(), i.peekQualifier),
+	}
+	if n, ok := t.(*types.Named); ok {
+		td.Named = true
+		if n.Obj().Pkg() != nil {
+			td.Package = n.Obj().Pkg().Path()
+		}
+	}
+
+	var elem, key types.Type
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		td.Kind = "basic"
+	case *types.Pointer:
+		td.Kind = "pointer"
+		elem = u.Elem()
+	case *types.Slice:
+		td.Kind = "slice"
+		elem = u.Elem()
+	case *types.Array:
+		td.Kind = "array"
+		elem = u.Elem()
+	case *types.Map:
+		td.Kind = "map"
+		key, elem = u.Key(), u.Elem()
+	case *types.Chan:
+		td.Kind = "chan"
+		elem = u.Elem()
+	case *types.Signature:
+		td.Kind = "func"
+	case *types.Struct:
+		td.Kind = "struct"
+	case *types.Interface:
+		td.Kind = "interface"
+	}
+
+	if depth > 1 {
+		if elem != nil {
+			e := i.describeType(elem, depth-1)
+			td.Elem = &e
+		}
+		if key != nil {
+			k := i.describeType(key, depth-1)
+			td.Key = &k
+		}
+	}
+	return td
+}
+
+// peekQualifier is a types.Qualifier which names packages as the generated
+// code would, without adding them to the imports
+func (i *imports) peekQualifier(p *types.Package) string {
+	if p.Path() == i.path {
+		return ""
+	}
+	if name, ok := i.byPath[p.Path()]; ok {
+		return name
+	}
+	return p.Name()
+}
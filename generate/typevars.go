@@ -0,0 +1,32 @@
+package generate
+
+import "github.com/object88/options/templates"
+
+// typeVars collects the package-level variables which hold the
+// `reflect.Type` of each struct in a generated file, so that every struct in
+// the file which refers to a type shares a single variable.  The variables
+// are declared in the package scope, so their names are chosen by the namer of
+// the package's declarations.
+type typeVars struct {
+	declared *namer
+	byType   map[string]string
+	vars     []templates.TypeVarData
+}
+
+func newTypeVars(declared *namer) *typeVars {
+	return &typeVars{declared: declared, byType: map[string]string{}}
+}
+
+// add returns the name of the variable for the type, declaring it with the
+// provided name, or a numbered one if that is taken, if the type does not have
+// one yet
+func (tv *typeVars) add(candidate, typeName string) string {
+	if existing, ok := tv.byType[typeName]; ok {
+		return existing
+	}
+	name := tv.declared.name(candidate)
+	tv.declared.reserve(name)
+	tv.byType[typeName] = name
+	tv.vars = append(tv.vars, templates.TypeVarData{Name: name, TypeName: typeName})
+	return name
+}
@@ -0,0 +1,6 @@
+package generate
+
+// Version is the version of the generator, which is recorded in the header
+// of each generated file.  Release builds set it with
+// `-ldflags "-X github.com/object88/options/generate.Version=..."`.
+var Version = "dev"
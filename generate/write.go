@@ -0,0 +1,167 @@
+package generate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// WriteResult describes what writing a generated file did
+type WriteResult int
+
+const (
+	// Created means that the file did not exist before
+	Created WriteResult = iota
+
+	// Updated means that the file existed with different content
+	Updated
+
+	// Unchanged means that the file already had the generated content, and was
+	// left alone
+	Unchanged
+)
+
+var writeResultNames = map[WriteResult]string{
+	Created:   "created",
+	Updated:   "updated",
+	Unchanged: "unchanged",
+}
+
+// String is the implementation of fmt.Stringer
+func (wr WriteResult) String() string {
+	return writeResultNames[wr]
+}
+
+// generatedHeader is the line which marks a file as generated code, which
+// must appear before the package clause
+var generatedHeader = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGenerated reports whether the content is marked as generated code, so
+// that it may be replaced without losing hand-written code
+func isGenerated(content []byte) bool {
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		if generatedHeader.Match(line) {
+			return true
+		}
+		if bytes.HasPrefix(line, []byte("package ")) {
+			break
+		}
+	}
+	return false
+}
+
+// versionHeader is the line of the generated header which records the
+// version of the generator
+var versionHeader = regexp.MustCompile(`^// Version: .*$`)
+
+// sameContent reports whether existing generated content is the same as the
+// new content.  The generator version is ignored, so that a differently built
+// generator does not mark every file as changed.
+func sameContent(existing, content []byte) bool {
+	return bytes.Equal(existing, content) || bytes.Equal(withoutVersion(existing), withoutVersion(content))
+}
+
+// withoutVersion returns the content without the version line of its header
+func withoutVersion(content []byte) []byte {
+	lines := bytes.Split(content, []byte("\n"))
+	for k, line := range lines {
+		if bytes.HasPrefix(line, []byte("package ")) {
+			break
+		}
+		if versionHeader.Match(line) {
+			lines = append(lines[:k:k], lines[k+1:]...)
+			break
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// checkOverwrite reports whether the existing file at the path may be
+// replaced by generated content
+func checkOverwrite(path string, existing []byte) error {
+	if !isGenerated(existing) {
+		return errors.Errorf("File '%s' exists and is not generated code; refusing to overwrite it", path)
+	}
+	return nil
+}
+
+// CompareFile reports what writing generated content to the path would do,
+// without writing it, and returns the file's existing content
+func CompareFile(fs afero.Fs, path string, content []byte) (WriteResult, []byte, error) {
+	existing, err := afero.ReadFile(fs, path)
+	switch {
+	case os.IsNotExist(err):
+		return Created, nil, nil
+	case err != nil:
+		return Created, nil, errors.Wrapf(err, "Failed to read existing file '%s'", path)
+	case sameContent(existing, content):
+		return Unchanged, existing, nil
+	}
+	if err := checkOverwrite(path, existing); err != nil {
+		return Updated, existing, err
+	}
+	return Updated, existing, nil
+}
+
+// WriteFile writes generated content to the path.  The content is written to
+// a temporary file in the same directory, which replaces the path only once
+// it is complete, so a failure never leaves a partial file behind.  A file
+// which already has the content, perhaps from another version of the
+// generator, is not touched, so that its modification time and any build
+// caches survive.  An existing file which is not marked
+// as generated code is never replaced.  Missing directories are created.
+func WriteFile(fs afero.Fs, path string, content []byte) (WriteResult, error) {
+	result := Created
+	mode := os.FileMode(0644)
+
+	fi, err := fs.Stat(path)
+	switch {
+	case err == nil:
+		existing, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return result, errors.Wrapf(err, "Failed to read existing file '%s'", path)
+		}
+		if sameContent(existing, content) {
+			return Unchanged, nil
+		}
+		result = Updated
+		if err := checkOverwrite(path, existing); err != nil {
+			return result, err
+		}
+		mode = fi.Mode().Perm()
+	case !os.IsNotExist(err):
+		return result, errors.Wrapf(err, "Failed to stat '%s'", path)
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return result, errors.Wrapf(err, "Failed to create directory for '%s'", path)
+	}
+
+	f, err := afero.TempFile(fs, filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return result, errors.Wrapf(err, "Failed to create temporary file for '%s'", path)
+	}
+	tmpPath := f.Name()
+
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = fs.Chmod(tmpPath, mode)
+	}
+	if err == nil {
+		err = fs.Rename(tmpPath, path)
+	}
+	if err != nil {
+		fs.Remove(tmpPath)
+		return result, errors.Wrapf(err, "Failed to write '%s'", path)
+	}
+
+	return result, nil
+}
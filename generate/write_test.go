@@ -0,0 +1,176 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func Test_WriteFile(t *testing.T) {
+	tcs := []struct {
+		name     string
+		existing *string
+		content  string
+		expected WriteResult
+		err      bool
+	}{
+		{
+			name:     "Created",
+			content:  "package foo\n",
+			expected: Created,
+		},
+		{
+			name:     "Updated",
+			existing: stringPtr("// Code generated by options; DO NOT EDIT.\n\npackage bar\n"),
+			content:  "package foo\n",
+			expected: Updated,
+		},
+		{
+			name:     "Other version",
+			existing: stringPtr("// Code generated by options; DO NOT EDIT.\n//\n// Version: v1.0.0\n\npackage foo\n"),
+			content:  "// Code generated by options; DO NOT EDIT.\n//\n// Version: v1.1.0\n\npackage foo\n",
+			expected: Unchanged,
+		},
+		{
+			name:     "Hand-written",
+			existing: stringPtr("package bar\n\n// Code generated by hand; DO NOT EDIT.\n"),
+			content:  "package foo\n",
+			err:      true,
+		},
+		{
+			name:     "Unchanged",
+			existing: stringPtr("package foo\n"),
+			content:  "package foo\n",
+			expected: Unchanged,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			path := "/src/foo/foo_gen.go"
+			if tc.existing != nil {
+				if err := afero.WriteFile(fs, path, []byte(*tc.existing), 0600); err != nil {
+					t.Fatalf("Failed to set up test; did not write existing file: %s", err.Error())
+				}
+			}
+
+			result, err := WriteFile(fs, path, []byte(tc.content))
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error from WriteFile")
+				}
+				actual, _ := afero.ReadFile(fs, path)
+				if string(actual) != *tc.existing {
+					t.Errorf("Expected WriteFile not to change the file")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error from WriteFile: %s", err.Error())
+			}
+			if result != tc.expected {
+				t.Errorf("Expected result '%s', got '%s'", tc.expected, result)
+			}
+
+			actual, err := afero.ReadFile(fs, path)
+			if err != nil {
+				t.Fatalf("Failed to read written file: %s", err.Error())
+			}
+			expected := tc.content
+			if tc.expected == Unchanged {
+				expected = *tc.existing
+			}
+			if string(actual) != expected {
+				t.Errorf("Expected content '%s', got '%s'", expected, string(actual))
+			}
+
+			fis, err := afero.ReadDir(fs, "/src/foo")
+			if err != nil {
+				t.Fatalf("Failed to read directory: %s", err.Error())
+			}
+			if len(fis) != 1 {
+				t.Errorf("Expected only the written file, found %d files", len(fis))
+			}
+
+			if tc.existing != nil {
+				fi, err := fs.Stat(path)
+				if err != nil {
+					t.Fatalf("Failed to stat written file: %s", err.Error())
+				}
+				if fi.Mode().Perm() != 0600 {
+					t.Errorf("Expected file mode to be preserved; got %s", fi.Mode())
+				}
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func Test_CompareFile(t *testing.T) {
+	tcs := []struct {
+		name     string
+		existing *string
+		expected WriteResult
+		err      bool
+	}{
+		{
+			name:     "Created",
+			expected: Created,
+		},
+		{
+			name:     "Updated",
+			existing: stringPtr("// Code generated by options; DO NOT EDIT.\n\npackage bar\n"),
+			expected: Updated,
+		},
+		{
+			name:     "Hand-written",
+			existing: stringPtr("package bar\n"),
+			err:      true,
+		},
+		{
+			name:     "Unchanged",
+			existing: stringPtr("package foo\n"),
+			expected: Unchanged,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			path := "/src/foo/foo_gen.go"
+			if tc.existing != nil {
+				if err := afero.WriteFile(fs, path, []byte(*tc.existing), 0644); err != nil {
+					t.Fatalf("Failed to set up test; did not write existing file: %s", err.Error())
+				}
+			}
+
+			result, existing, err := CompareFile(fs, path, []byte("package foo\n"))
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error from CompareFile")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error from CompareFile: %s", err.Error())
+			}
+			if result != tc.expected {
+				t.Errorf("Expected result '%s', got '%s'", tc.expected, result)
+			}
+			if tc.existing != nil && string(existing) != *tc.existing {
+				t.Errorf("Expected existing content '%s', got '%s'", *tc.existing, string(existing))
+			}
+
+			actual, err := afero.ReadFile(fs, path)
+			if tc.existing == nil && err == nil {
+				t.Errorf("Expected CompareFile not to create the file")
+			} else if tc.existing != nil && string(actual) != *tc.existing {
+				t.Errorf("Expected CompareFile not to change the file")
+			}
+		})
+	}
+}
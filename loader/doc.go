@@ -0,0 +1,116 @@
+package loader
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// toolDirective matches the text of a comment line which directs a tool,
+// such as `//go:generate` or `//nolint:errcheck`, rather than documenting
+var toolDirective = regexp.MustCompile(`^[a-z0-9]+:[a-z0-9]`)
+
+// TypeDocs holds the doc comment of a type, and the comments of its fields
+type TypeDocs struct {
+	Doc    string
+	Fields map[string]FieldDocs
+}
+
+// FieldDocs holds the doc comment above a struct field, and the line
+// comment after it
+type FieldDocs struct {
+	Doc     string
+	Comment string
+}
+
+// FindDocs returns the comments of the named type, and of each of its fields
+// by name, if it is a struct.  Embedded fields are named by their type.
+// Lines which consist of one of the directives, such as `generate-options`,
+// or which direct other tools, such as `go:generate`, are not documentation
+// and are removed.
+func (p *Package) FindDocs(typeName string, directives ...string) (*TypeDocs, bool) {
+	if p.docPkg == nil {
+		return nil, false
+	}
+
+	for _, t := range p.docPkg.Types {
+		if t.Name != typeName {
+			continue
+		}
+
+		docs := &TypeDocs{Doc: stripDirectives(t.Doc, directives), Fields: map[string]FieldDocs{}}
+		for _, spec := range t.Decl.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != typeName {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, f := range st.Fields.List {
+				fd := FieldDocs{
+					Doc:     stripDirectives(f.Doc.Text(), directives),
+					Comment: stripDirectives(f.Comment.Text(), directives),
+				}
+				for _, name := range f.Names {
+					docs.Fields[name.Name] = fd
+				}
+				if len(f.Names) == 0 {
+					docs.Fields[embeddedName(f.Type)] = fd
+				}
+			}
+		}
+		return docs, true
+	}
+
+	return nil, false
+}
+
+// embeddedName returns the field name of an embedded type expression
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	}
+	return types.ExprString(expr)
+}
+
+// stripDirectives removes the lines of comment text which are directives,
+// along with any blank lines they leave doubled or at either end
+func stripDirectives(text string, directives []string) string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if toolDirective.MatchString(trimmed) || isDirective(trimmed, directives) {
+			continue
+		}
+		if trimmed == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) != 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// isDirective reports whether the text of a comment line is one of the
+// directives
+func isDirective(text string, directives []string) bool {
+	for _, directive := range directives {
+		if text == directive {
+			return true
+		}
+	}
+	return false
+}
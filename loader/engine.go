@@ -108,8 +108,7 @@ func (le *Engine) processStateChange(sce *stateChangeEvent) {
 	case queued:
 		le.processDirectory(sce.l, p)
 
-		p.loadState.increment()
-		p.c.Broadcast()
+		p.advance()
 		le.stateChange <- sce
 	case unloaded:
 		importPaths := map[string]bool{}
@@ -121,8 +120,7 @@ func (le *Engine) processStateChange(sce *stateChangeEvent) {
 			le.processComplete(sce.l, p)
 		}
 
-		p.loadState.increment()
-		p.c.Broadcast()
+		p.advance()
 		le.stateChange <- sce
 	case done:
 		if sce.l.areAllPackagesComplete() {
@@ -1,8 +1,10 @@
 package loader
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
+	"strings"
 )
 
 // File is an AST file and any errors that types.Config.Check discovers
@@ -18,3 +20,14 @@ type FileError struct {
 	Message string
 	Warning bool
 }
+
+// FileErrors is a collection of errors found while checking a file
+type FileErrors []FileError
+
+func (fe FileErrors) Error() string {
+	msgs := make([]string, len(fe))
+	for k, e := range fe {
+		msgs[k] = fmt.Sprintf("%s: %s", e.Position, e.Message)
+	}
+	return strings.Join(msgs, "\n")
+}
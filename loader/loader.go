@@ -226,6 +226,12 @@ func (l *Loader) ensurePackage(absPath string) (*Package, bool) {
 	return p, created
 }
 
+// ReadFile returns the content of the file, read through the loader's
+// file system
+func (l *Loader) ReadFile(absPath string) ([]byte, error) {
+	return afero.ReadFile(l.fs, absPath)
+}
+
 // FindPackage will locate the package at the provided path
 func (l *Loader) FindPackage(absPath string) (*Package, error) {
 	chash := collections.CalculateHashFromString(absPath)
@@ -237,6 +243,22 @@ func (l *Loader) FindPackage(absPath string) (*Package, error) {
 	return p, nil
 }
 
+// Packages returns every package which the loader has loaded, including
+// the packages they import, ordered by path
+func (l *Loader) Packages() []*Package {
+	l.m.Lock()
+	ps := make([]*Package, 0, len(l.PackageHashSet))
+	for chash := range l.PackageHashSet {
+		if n, ok := l.le.caravan.Find(chash); ok {
+			ps = append(ps, n.Element.(*Package))
+		}
+	}
+	l.m.Unlock()
+
+	sort.Slice(ps, func(i, j int) bool { return ps[i].AbsPath < ps[j].AbsPath })
+	return ps
+}
+
 // FindImportPath is used by the loader engine locate the path to a packge
 // imported by the specified package
 func (l *Loader) FindImportPath(p *Package, importPath string) (string, error) {
@@ -253,6 +275,29 @@ func (l *Loader) FindImportPath(p *Package, importPath string) (string, error) {
 	return targetPath, nil
 }
 
+// FindImportDirectory returns the directory of the package with the import
+// path, as imported from the source directory
+func (l *Loader) FindImportDirectory(importPath, srcDir string) (string, error) {
+	return l.findImportPath(importPath, srcDir)
+}
+
+// loadImport ensures that the package imported by the provided package is
+// loaded, and blocks until it has been checked.  Imports which cannot be
+// found are left for the type checker to report.
+func (l *Loader) loadImport(p *Package, importPath string) {
+	targetPath, err := l.FindImportPath(p, importPath)
+	if err != nil || targetPath == "" {
+		return
+	}
+	l.le.ensurePackage(l, targetPath)
+
+	targetP, err := l.FindPackage(targetPath)
+	if err != nil {
+		return
+	}
+	targetP.WaitUntilDone()
+}
+
 // LoadDirectory adds the contents of a directory to the Loader
 func (l *Loader) LoadDirectory(startDir string) error {
 	// if strings.HasPrefix(startDir, "file://") {
@@ -274,6 +319,25 @@ func (l *Loader) LoadDirectory(startDir string) error {
 	return nil
 }
 
+// LoadPackage adds the package in a directory to the Loader, along with the
+// packages it imports, but not the packages in its subdirectories
+func (l *Loader) LoadPackage(dir string) error {
+	absPath, err := filepath.Abs(dir)
+	if err != nil {
+		return errors.Wrapf(err, "Could not get absolute path for '%s'", dir)
+	}
+
+	if !l.context.IsDir(absPath) {
+		return fmt.Errorf("Argument '%s' is not a directory", absPath)
+	}
+
+	l.StartDir = absPath
+	l.Log.Verbosef("Loader.LoadPackage: reading package '%s'\n", l.StartDir)
+	l.le.ensurePackage(l, l.StartDir)
+
+	return nil
+}
+
 func (l *Loader) isAllowed(absPath string) bool {
 	for _, g := range l.filteredPaths {
 		if g.Match(absPath) {
@@ -337,6 +401,9 @@ func (l *Loader) HandleTypeCheckerError(e error) {
 func (l *Loader) findImportPath(path, src string) (string, error) {
 	buildPkg, err := l.context.Import(path, src, build.FindOnly)
 	if err != nil {
+		if dir, ok := l.findModuleImportPath(path, src); ok {
+			return dir, nil
+		}
 		msg := fmt.Sprintf("Failed to find import path:\n\tAttempted build.Import('%s', '%s', build.FindOnly)", path, src)
 		return "", errors.Wrap(err, msg)
 	}
@@ -0,0 +1,140 @@
+package loader
+
+import (
+	"bufio"
+	"go/build"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// findModuleImportPath resolves an import path against the module which
+// contains the source directory, looking within the module itself and then
+// within its vendor directory.  The build.Context does not resolve module
+// paths when its file system hooks are set.
+func (l *Loader) findModuleImportPath(path, src string) (string, bool) {
+	root, modulePath, ok := l.findModule(src)
+	if !ok {
+		return "", false
+	}
+
+	var dir string
+	switch {
+	case path == modulePath:
+		dir = root
+	case strings.HasPrefix(path, modulePath+"/"):
+		dir = filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(path, modulePath+"/")))
+	default:
+		dir = filepath.Join(root, "vendor", filepath.FromSlash(path))
+	}
+
+	if !l.context.IsDir(dir) {
+		return "", false
+	}
+	return dir, true
+}
+
+// findPackageImportPath returns the import path for a package.  Packages
+// within a module, or within a module's vendor directory, are given the path
+// by which the module imports them.
+func (l *Loader) findPackageImportPath(buildPkg *build.Package) string {
+	if buildPkg.Goroot {
+		return buildPkg.ImportPath
+	}
+
+	if importPath, ok := l.findModuleDirImportPath(buildPkg.Dir); ok {
+		return importPath
+	}
+
+	if buildPkg.ImportPath != "" && buildPkg.ImportPath != "." && !build.IsLocalImport(buildPkg.ImportPath) {
+		return buildPkg.ImportPath
+	}
+	return buildPkg.Dir
+}
+
+// FindDirectoryImportPath returns the import path for the directory, which
+// need not contain a package yet
+func (l *Loader) FindDirectoryImportPath(absPath string) string {
+	if p, err := l.FindPackage(absPath); err == nil && p.ImportPath != "" {
+		return p.ImportPath
+	}
+	if importPath, ok := l.findModuleDirImportPath(absPath); ok {
+		return importPath
+	}
+	if buildPkg, err := l.context.Import(".", absPath, build.FindOnly); err == nil && !build.IsLocalImport(buildPkg.ImportPath) {
+		return buildPkg.ImportPath
+	}
+	return absPath
+}
+
+// FindDirectoryPackageName returns the name of the package declared by the
+// Go files in the directory, if there are any
+func (l *Loader) FindDirectoryPackageName(absPath string) (string, bool) {
+	if !l.context.IsDir(absPath) {
+		return "", false
+	}
+	buildPkg, err := l.context.Import(".", absPath, 0)
+	if err != nil || buildPkg.Name == "" {
+		return "", false
+	}
+	return buildPkg.Name, true
+}
+
+// findModuleDirImportPath returns the import path for a directory within a
+// module or within a module's vendor directory
+func (l *Loader) findModuleDirImportPath(dir string) (string, bool) {
+	root, modulePath, ok := l.findModule(dir)
+	if !ok {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", false
+	}
+
+	rel = filepath.ToSlash(rel)
+	switch {
+	case rel == ".":
+		return modulePath, true
+	case strings.HasPrefix(rel, "vendor/"):
+		return strings.TrimPrefix(rel, "vendor/"), true
+	}
+	return modulePath + "/" + rel, true
+}
+
+// findModule walks up from the provided directory to the nearest `go.mod`,
+// and returns the module's root directory and path
+func (l *Loader) findModule(dir string) (string, string, bool) {
+	for {
+		if modulePath, ok := l.readModulePath(filepath.Join(dir, "go.mod")); ok {
+			return dir, modulePath, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+func (l *Loader) readModulePath(gomod string) (string, bool) {
+	r, err := l.context.OpenFile(gomod)
+	if err != nil {
+		return "", false
+	}
+	defer r.Close()
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		if modulePath, err := strconv.Unquote(fields[1]); err == nil {
+			return modulePath, true
+		}
+		return fields[1], true
+	}
+	return "", false
+}
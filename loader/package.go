@@ -5,8 +5,13 @@ import (
 	"go/ast"
 	"go/build"
 	"go/doc"
+	"go/parser"
 	"go/token"
 	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/object88/options/loader/collections"
@@ -15,8 +20,9 @@ import (
 
 // Package contains the os/arch specific package AST
 type Package struct {
-	AbsPath string
-	hash    collections.Hash
+	AbsPath    string
+	ImportPath string
+	hash       collections.Hash
 
 	Fset *token.FileSet
 
@@ -61,7 +67,7 @@ func (p *Package) check() error {
 			// Uses: map[*ast.Ident]types.Object{},
 		}
 
-		p.typesPkg = types.NewPackage(p.AbsPath, p.buildPkg.Name)
+		p.typesPkg = types.NewPackage(p.ImportPath, p.buildPkg.Name)
 		p.checker = types.NewChecker(p.l.config, p.Fset, p.typesPkg, info)
 	}
 
@@ -100,6 +106,7 @@ func (p *Package) generateBuildPackage() error {
 	}
 
 	p.buildPkg = buildPkg
+	p.ImportPath = p.l.findPackageImportPath(buildPkg)
 	return nil
 }
 
@@ -112,6 +119,11 @@ func (p *Package) Name() string {
 	return p.astPkg.Name
 }
 
+// Types returns the type-checked package
+func (p *Package) Types() *types.Package {
+	return p.typesPkg
+}
+
 func (p *Package) String() string {
 	return fmt.Sprintf("%s %s", p.l.getTags(), p.AbsPath)
 }
@@ -143,6 +155,194 @@ func (p *Package) FindSource(structName string) (string, *types.Struct, error) {
 	return "", nil, errors.Errorf("Failed to locate struct '%s' within package '%s'", structName, p.Name())
 }
 
+// FindDeclaration returns the source of the declaration of the named type,
+// from its doc comment to its line comment, and the name of the file which
+// holds it.  The rest of the file is not part of it.
+func (p *Package) FindDeclaration(typeName string) (string, []byte, error) {
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != typeName {
+					continue
+				}
+
+				// An ungrouped declaration has its doc comment on the `type`
+				// keyword instead of the spec.
+				start, end := ts.Pos(), ts.End()
+				switch {
+				case ts.Doc != nil:
+					start = ts.Doc.Pos()
+				case !gd.Lparen.IsValid() && gd.Doc != nil:
+					start = gd.Doc.Pos()
+				}
+				if ts.Comment != nil {
+					end = ts.Comment.End()
+				}
+
+				filename := p.Fset.File(start).Name()
+				src, err := p.l.ReadFile(filename)
+				if err != nil {
+					return "", nil, errors.Wrapf(err, "Failed to read source file '%s'", filename)
+				}
+				return filename, src[p.Fset.Position(start).Offset:p.Fset.Position(end).Offset], nil
+			}
+		}
+	}
+
+	return "", nil, errors.Errorf("Failed to locate type '%s' within package '%s'", typeName, p.Name())
+}
+
+// FindDirective returns the names of the types whose doc comments have a
+// line consisting of the directive, such as `// generate-options`
+func (p *Package) FindDirective(directive string) []string {
+	names := []string{}
+	if p.docPkg == nil {
+		return names
+	}
+
+	for _, t := range p.docPkg.Types {
+		for _, line := range strings.Split(t.Doc, "\n") {
+			if strings.TrimSpace(line) == directive {
+				names = append(names, t.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// advance moves the package to its next load state, and wakes anything
+// waiting on it
+func (p *Package) advance() {
+	p.m.Lock()
+	p.loadState.increment()
+	p.c.Broadcast()
+	p.m.Unlock()
+}
+
+// WaitUntilDone blocks until this package has been loaded and checked
+func (p *Package) WaitUntilDone() {
+	p.m.Lock()
+	for p.loadState.get() != done {
+		p.c.Wait()
+	}
+	p.m.Unlock()
+}
+
+// CheckFiles type-checks the package with the provided sources added as the
+// named files, replacing any existing files of the same names, so that
+// sources which refer to each other are checked together.  Packages which
+// the sources import are loaded as needed.  Only errors located within the
+// provided sources are returned.
+func (p *Package) CheckFiles(srcs map[string][]byte) error {
+	filenames := []string{}
+	for filename := range srcs {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	fpaths := map[string]bool{}
+	checked := make([]*ast.File, 0, len(filenames))
+	for _, filename := range filenames {
+		fpath := filepath.Join(p.AbsPath, filename)
+		astf, err := parser.ParseFile(p.Fset, fpath, srcs[filename], parser.ParseComments|parser.AllErrors)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range astf.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			p.l.loadImport(p, importPath)
+		}
+		fpaths[fpath] = true
+		checked = append(checked, astf)
+	}
+
+	names := []string{}
+	for name := range p.files {
+		if _, ok := srcs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	astFiles := make([]*ast.File, 0, len(names)+len(checked))
+	for _, name := range names {
+		astFiles = append(astFiles, p.files[name])
+	}
+	// The new files are checked last, so that they are the ones which report
+	// any redeclarations.
+	astFiles = append(astFiles, checked...)
+
+	errs := FileErrors{}
+	config := &types.Config{
+		Importer: p.l.config.Importer,
+		Error: func(e error) {
+			terr, ok := e.(types.Error)
+			if !ok {
+				return
+			}
+			position := terr.Fset.Position(terr.Pos)
+			if !fpaths[position.Filename] || strings.HasPrefix(terr.Msg, "could not import") {
+				// Imports which the loader cannot resolve are not the fault of the
+				// new files; any use of them is already reported as invalid.
+				return
+			}
+			errs = append(errs, FileError{Position: position, Message: terr.Msg, Warning: terr.Soft})
+		},
+	}
+
+	p.m.Lock()
+	config.Check(p.ImportPath, p.Fset, astFiles, nil)
+	p.m.Unlock()
+
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// Inspect type-checks the package without the named files, such as the
+// previous output of a generator, so that only the declarations in the
+// remaining files are found.  Errors are ignored, since the remaining files
+// may refer to declarations in the excluded ones.
+func (p *Package) Inspect(exclude ...string) *types.Package {
+	excluded := map[string]bool{}
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+
+	names := []string{}
+	for name := range p.files {
+		if !excluded[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	astFiles := make([]*ast.File, len(names))
+	for k, name := range names {
+		astFiles[k] = p.files[name]
+	}
+
+	config := &types.Config{
+		Importer: p.l.config.Importer,
+		Error:    func(e error) {},
+	}
+
+	p.m.Lock()
+	pkg, _ := config.Check(p.ImportPath, p.Fset, astFiles, nil)
+	p.m.Unlock()
+
+	return pkg
+}
+
 // WaitUntilReady blocks until this package has loaded sufficiently for the
 // requested load state.
 func (p *Package) WaitUntilReady(loadState loadState) {
@@ -12,3 +12,9 @@ type Option interface {
 	TargetType() reflect.Type
 	Apply(target interface{}) error
 }
+
+// Defaulter is implemented by options structs which can set the default
+// values of their fields.
+type Defaulter interface {
+	ApplyDefaults()
+}
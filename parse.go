@@ -0,0 +1,118 @@
+package options
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The parse helpers are used by the generated `FromString` setters, which
+// parse human input such as "5s" or "64MiB".  A bit size of zero is the size
+// of `int` or `uint`.
+
+// ParseInt parses a signed integer which fits in the bit size.  The base is
+// implied by its prefix, such as `0x`.
+func ParseInt(s string, bitSize int) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(s), 0, bitSize)
+}
+
+// ParseUint parses an unsigned integer which fits in the bit size.  The base
+// is implied by its prefix, such as `0x`.
+func ParseUint(s string, bitSize int) (uint64, error) {
+	return strconv.ParseUint(strings.TrimSpace(s), 0, bitSize)
+}
+
+// ParseFloat parses a floating-point number of the bit size
+func ParseFloat(s string, bitSize int) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(s), bitSize)
+}
+
+// ParseDuration parses a duration such as "300ms" or "1h30m"
+func ParseDuration(s string) (time.Duration, error) {
+	return time.ParseDuration(strings.TrimSpace(s))
+}
+
+// byteSizeUnits are the units of byte sizes, in decimal and binary
+// multiples, which are matched without regard to case
+var byteSizeUnits = []struct {
+	name       string
+	multiplier uint64
+}{
+	{"", 1},
+	{"B", 1},
+	{"KB", 1e3},
+	{"MB", 1e6},
+	{"GB", 1e9},
+	{"TB", 1e12},
+	{"PB", 1e15},
+	{"EB", 1e18},
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"TiB", 1 << 40},
+	{"PiB", 1 << 50},
+	{"EiB", 1 << 60},
+}
+
+// ParseByteSize parses a size in bytes, such as "512", "10KB" or "1.5GiB",
+// which fits in an unsigned integer of the bit size
+func ParseByteSize(s string, bitSize int) (uint64, error) {
+	n, err := parseByteSize(s, unsignedBits(bitSize))
+	if err != nil {
+		return 0, err
+	}
+	return n.Uint64(), nil
+}
+
+// ParseByteSizeInt parses a size in bytes, such as "512", "10KB" or
+// "1.5GiB", which fits in a signed integer of the bit size
+func ParseByteSizeInt(s string, bitSize int) (int64, error) {
+	n, err := parseByteSize(s, unsignedBits(bitSize)-1)
+	if err != nil {
+		return 0, err
+	}
+	return n.Int64(), nil
+}
+
+// parseByteSize parses a size in bytes which fits in the number of bits
+func parseByteSize(s string, bits int) (*big.Int, error) {
+	number, unit := strings.TrimSpace(s), ""
+	if i := strings.IndexFunc(number, unicode.IsLetter); i != -1 {
+		number, unit = strings.TrimSpace(number[:i]), number[i:]
+	}
+
+	var multiplier uint64
+	for _, u := range byteSizeUnits {
+		if strings.EqualFold(u.name, unit) {
+			multiplier = u.multiplier
+			break
+		}
+	}
+	if multiplier == 0 {
+		return nil, fmt.Errorf("Unknown unit '%s' in byte size '%s'", unit, s)
+	}
+
+	r, ok := new(big.Rat).SetString(number)
+	if !ok || strings.Contains(number, "/") || r.Sign() < 0 {
+		return nil, fmt.Errorf("Invalid byte size '%s'", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(multiplier)))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("Byte size '%s' is not a whole number of bytes", s)
+	}
+	if r.Num().BitLen() > bits {
+		return nil, fmt.Errorf("Byte size '%s' is out of range", s)
+	}
+	return r.Num(), nil
+}
+
+// unsignedBits returns the bit size, where zero is the size of `uint`
+func unsignedBits(bitSize int) int {
+	if bitSize == 0 {
+		return strconv.IntSize
+	}
+	return bitSize
+}
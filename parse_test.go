@@ -0,0 +1,57 @@
+package options
+
+import "testing"
+
+func Test_ParseByteSize(t *testing.T) {
+	tcs := []struct {
+		name     string
+		input    string
+		bitSize  int
+		expected uint64
+		err      bool
+	}{
+		{name: "Bytes", input: "512", bitSize: 64, expected: 512},
+		{name: "Byte unit", input: "512B", bitSize: 64, expected: 512},
+		{name: "Decimal", input: "10KB", bitSize: 64, expected: 10000},
+		{name: "Binary", input: "64MiB", bitSize: 64, expected: 64 << 20},
+		{name: "Fraction", input: "1.5GiB", bitSize: 64, expected: 3 << 29},
+		{name: "Space and case", input: " 2 kib ", bitSize: 64, expected: 2048},
+		{name: "Largest", input: "255", bitSize: 8, expected: 255},
+		{name: "Out of range", input: "256", bitSize: 8, err: true},
+		{name: "Partial byte", input: "1.5", bitSize: 64, err: true},
+		{name: "Negative", input: "-1KB", bitSize: 64, err: true},
+		{name: "Unknown unit", input: "5 parsecs", bitSize: 64, err: true},
+		{name: "Empty", input: "", bitSize: 64, err: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseByteSize(tc.input, tc.bitSize)
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error, got %d", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err.Error())
+			}
+			if actual != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_ParseByteSizeInt(t *testing.T) {
+	if _, err := ParseByteSizeInt("128", 8); err == nil {
+		t.Errorf("Expected error for a size which overflows int8")
+	}
+	actual, err := ParseByteSizeInt("1KiB", 16)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if actual != 1024 {
+		t.Errorf("Expected 1024, got %d", actual)
+	}
+}
@@ -0,0 +1,25 @@
+package options
+
+// PresenceFields is the number of fields whose presence a `Presence` can
+// record.  The generator refuses to track the presence of the fields of a
+// struct with more fields with setters than this.
+const PresenceFields = 256
+
+// Presence records which fields of an options struct have been set by its
+// options, so that a field set to its zero value can be told apart from one
+// which was never set.  An options struct opts in to presence tracking by
+// declaring a field of this type, which the generated setters record into.
+// The zero value records no fields.
+type Presence struct {
+	bits [PresenceFields / 64]uint64
+}
+
+// Set records that the field with the index has been set
+func (p *Presence) Set(index int) {
+	p.bits[index/64] |= 1 << uint(index%64)
+}
+
+// IsSet reports whether the field with the index has been set
+func (p *Presence) IsSet(index int) bool {
+	return p.bits[index/64]&(1<<uint(index%64)) != 0
+}
@@ -1,16 +1,137 @@
 package templates
 
+import "reflect"
+
+// Data is the generated code for a single file, which holds the options of
+// one or more structs
 type Data struct {
-	Package       string
-	InstanceName  string
-	StructName    string
-	StructMembers []FuncData
+	Version        string
+	Sources        []string
+	InputHash      string
+	Package        string
+	ImportGroups   [][]ImportData
+	MethodSetters  bool
+	StaticDispatch bool
+	Setters        bool
+	Methods        bool
+	TypeVars       []TypeVarData
+	Structs        []StructData
+}
+
+// StructData is the generated code for a single options struct.  The
+// constructor name is empty when a hand-written constructor takes its place.
+// Presence is the name of the field which records the fields set by options,
+// if the struct tracks them.  Defaults are set by `ApplyDefaults`, including
+// those of fields which have no setter.  OptName is the type of the struct's
+// options, and OptRef refers to it from the setters.
+type StructData struct {
+	Doc             string
+	InstanceName    string
+	StructName      string
+	StructRef       string
+	OptName         string
+	OptRef          string
+	ConstructorName string
+	TypeVar         string
+	HasValidate     bool
+	Presence        string
+	StructMembers   []FuncData
+	Defaults        []DefaultData
+	Embedded        []EmbeddedData
 }
 
+// FuncData is a field of an options struct, which has a setter.  The setter
+// name is empty when a hand-written setter takes its place.  Slice fields
+// also have a setter which appends to them, and map fields have setters which
+// put and delete entries, bool fields have setters which enable and disable
+// them, and numeric fields have a setter which parses a string, using the
+// `Parse` call and assigning `Parsed`; their names are empty otherwise.  When the struct
+// tracks the presence of its fields, the field's presence is recorded at the
+// index.
 type FuncData struct {
 	InstanceName    string
+	Doc             string
+	Comment         string
+	Tag             reflect.StructTag
+	Type            TypeData
 	OptionName      string
 	OptionNameLower string
 	OptionNameUpper string
 	OptionType      string
+	SetterName      string
+	AppendName      string
+	PutName         string
+	DeleteName      string
+	KeyType         string
+	ElemType        string
+	KeyParam        string
+	ElemParam       string
+	EnableName      string
+	DisableName     string
+	FromStringName  string
+	StringParam     string
+	ParsedName      string
+	Parse           string
+	Parsed          string
+	Default         string
+	HasDefault      bool
+	Shim            string
+	Index           int
+	IsSetName       string
+}
+
+// DefaultData is a field of an options struct which has a `default` tag, and
+// the Go literal which is assigned to it
+type DefaultData struct {
+	OptionName string
+	Value      string
+}
+
+// EmbeddedData describes a struct, embedded directly or through other
+// embedded structs, which can be the target of options applied to the
+// embedding struct.  Direct is true for a struct embedded directly.
+type EmbeddedData struct {
+	Selector string
+	Direct   bool
+	TypeVar  string
+	TypeName string
+	Pointer  bool
+	Pointers []EmbeddedPointer
+	Validate bool
+	Defaults bool
+}
+
+// EmbeddedPointer is an embedded pointer along the path to an embedded
+// struct, which must be allocated before the struct can be reached
+type EmbeddedPointer struct {
+	Selector string
+	TypeName string
+	Defaults bool
+}
+
+// ImportData is a single import in the generated file
+type ImportData struct {
+	Alias string
+	Path  string
+}
+
+// TypeVarData is a package-level variable which holds the `reflect.Type` of
+// a struct, shared by every struct in the file which refers to it
+type TypeVarData struct {
+	Name     string
+	TypeName string
+}
+
+// TypeData describes the type of a field.  String is the type as written in
+// the generated file.  Kind is the kind of its underlying type; one of
+// `basic`, `pointer`, `slice`, `array`, `map`, `chan`, `func`, `struct` or
+// `interface`.  Elem and Key describe the types it is built from.
+type TypeData struct {
+	String     string
+	Underlying string
+	Kind       string
+	Named      bool
+	Package    string
+	Elem       *TypeData
+	Key        *TypeData
 }
@@ -0,0 +1,93 @@
+package options
+
+import (
+	"strings"
+)
+
+// Validator is implemented by options structs which can check their values
+// once all options have been applied.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError may be returned from a `validate` method to name the field
+// which failed validation.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the field's error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is a single validation failure, naming the struct and, if
+// known, the field which failed.
+type ValidationError struct {
+	Struct string
+	Field  string
+	Err    error
+}
+
+func (e *ValidationError) Error() string {
+	name := e.Struct
+	if e.Field != "" {
+		name += "." + e.Field
+	}
+	return name + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors aggregates every failure found while validating an options
+// struct and its embedded structs.
+type ValidationErrors []*ValidationError
+
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for k, e := range ve {
+		msgs[k] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Append adds the error returned from validating the named struct.  Nested
+// `ValidationErrors` are flattened, and any of their failures without a
+// struct name are attributed to `structName`.  A `*FieldError` names the
+// failing field.  A nil error is ignored.
+func (ve ValidationErrors) Append(structName string, err error) ValidationErrors {
+	switch e := err.(type) {
+	case nil:
+	case ValidationErrors:
+		for _, inner := range e {
+			if inner.Struct == "" {
+				inner.Struct = structName
+			}
+			ve = append(ve, inner)
+		}
+	case *ValidationError:
+		return ve.Append(structName, ValidationErrors{e})
+	case *FieldError:
+		ve = append(ve, &ValidationError{Struct: structName, Field: e.Field, Err: e.Err})
+	default:
+		ve = append(ve, &ValidationError{Struct: structName, Err: err})
+	}
+	return ve
+}
+
+// ErrorOrNil returns nil if there are no failures, so that an empty
+// `ValidationErrors` is not returned as a non-nil error.
+func (ve ValidationErrors) ErrorOrNil() error {
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}